@@ -0,0 +1,123 @@
+package go3s
+
+import "context"
+
+//go:generate go run ./internal/mockgen -out mock_api.go
+
+// API is every Solscan endpoint of Client, see the domain interfaces
+// to depend on a part of them only. MockClient implements all of them for tests.
+type API interface {
+	AccountAPI
+	TokenAPI
+	NFTAPI
+	TransactionAPI
+	BlockAPI
+	MarketAPI
+	MonitorAPI
+}
+
+// AccountAPI covers the /account endpoints.
+type AccountAPI interface {
+	AccountTransfers(ctx context.Context, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersIter(ctx context.Context, address string, optParams *AccountTransfersParams) *Iterator[Transfer]
+	AccountTransfersShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersStableQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransfersParams) ([]Transfer, []BlockTimeGap, error)
+	AccountTokenAccounts(ctx context.Context, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error)
+	AccountTokenAccountsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error)
+	AccountTokenAccountsIter(ctx context.Context, address string, optParams *AccountTokenAccountsParams) *Iterator[TokenAccount]
+	AccountDefiActivities(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountDefiActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountDefiActivitiesIter(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) *Iterator[DefiActivity]
+	AccountDefiActivitiesShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountBalanceChanges(ctx context.Context, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountBalanceChangesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountBalanceChangesIter(ctx context.Context, address string, optParams *AccountBalanceChangesParams) *Iterator[AccountChangeActivity]
+	AccountBalanceChangesShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountTransactions(ctx context.Context, address string, optParams *AccountTransactionsParams) ([]Transaction, error)
+	AccountTransactionsPagingQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransactionsParams) ([]Transaction, error)
+	AccountTransactionsIter(ctx context.Context, address string, optParams *AccountTransactionsParams) *Iterator[Transaction]
+	AccountStakes(ctx context.Context, address string, optParams *AccountStakesParams) ([]AccountStake, error)
+	AccountStakesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountStakesParams) ([]AccountStake, error)
+	AccountStakesIter(ctx context.Context, address string, optParams *AccountStakesParams) *Iterator[AccountStake]
+	AccountDetail(ctx context.Context, address string) (AccountDetail, error)
+	BatchAccountDetail(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]AccountDetail, map[string]error, error)
+	AccountRewardsExport(ctx context.Context, address string, timeFrom, timeTo int64) ([]byte, error)
+	AccountTransfersExport(ctx context.Context, address string, optParams *AccountTransfersExportParams) ([]byte, error)
+}
+
+// TokenAPI covers the /token endpoints.
+type TokenAPI interface {
+	TokenTransfers(ctx context.Context, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenTransfersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenTransfersIter(ctx context.Context, address string, optParams *TokenTransfersParams) *Iterator[Transfer]
+	TokenTransfersShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenDefiActivities(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error)
+	TokenDefiActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error)
+	TokenDefiActivitiesIter(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) *Iterator[DefiActivity]
+	TokenMarkets(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) ([]Market, error)
+	TokenMarketsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, token_pair []string, optParams *TokenMarketsParams) ([]Market, error)
+	TokenMarketsIter(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) *Iterator[Market]
+	TokenList(ctx context.Context, optParams *TokenListParams) ([]Token, error)
+	TokenListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *TokenListParams) ([]Token, error)
+	TokenListIter(ctx context.Context, optParams *TokenListParams) *Iterator[Token]
+	TokenTrending(ctx context.Context, limit int64) ([]Token, error)
+	TokenPrice(ctx context.Context, address, startTime, endTime string) ([]TokenPrice, error)
+	TokenHolders(ctx context.Context, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error)
+	TokenHoldersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error)
+	TokenHoldersIter(ctx context.Context, address string, optParams *TokenHoldersParams) *Iterator[TokenHolder]
+	TokenMeta(ctx context.Context, address string) (TokenMeta, error)
+	BatchTokenMeta(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]TokenMeta, map[string]error, error)
+	TokenTop(ctx context.Context) ([]TokenTop, error)
+}
+
+// NFTAPI covers the /nft endpoints.
+type NFTAPI interface {
+	NFTNews(ctx context.Context, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error)
+	NFTNewsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error)
+	NFTNewsIter(ctx context.Context, optParams *NFTNewsParams) *Iterator[NFTInfo]
+	NFTActivities(ctx context.Context, optParams *NFTActivitiesParams) ([]NFTActivity, error)
+	NFTActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTActivitiesParams) ([]NFTActivity, error)
+	NFTActivitiesIter(ctx context.Context, optParams *NFTActivitiesParams) *Iterator[NFTActivity]
+	NFTCollectionList(ctx context.Context, optParams *NFTCollectionListParams) ([]NFTCollection, error)
+	NFTCollectionListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTCollectionListParams) ([]NFTCollection, error)
+	NFTCollectionListIter(ctx context.Context, optParams *NFTCollectionListParams) *Iterator[NFTCollection]
+	NFTCollectionItems(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error)
+	NFTCollectionItemsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error)
+	NFTCollectionItemsIter(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) *Iterator[NFTCollectionItem]
+}
+
+// TransactionAPI covers the /transaction endpoints.
+type TransactionAPI interface {
+	TxLast(ctx context.Context, optParams *TxLastParams) ([]Transaction, error)
+	TxDetail(ctx context.Context, tx string) (TransactionDetail, error)
+	BatchTxDetail(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionDetail, map[string]error, error)
+	TxActions(ctx context.Context, tx string) (TransactionAction, error)
+	BatchTxActions(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionAction, map[string]error, error)
+}
+
+// BlockAPI covers the /block endpoints and the chain info.
+type BlockAPI interface {
+	ChainInfo(ctx context.Context) (ChainInfo, error)
+	BlocksLast(ctx context.Context, limit LargePageSize) ([]BlockDetail, error)
+	BlockTransactions(ctx context.Context, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error)
+	BlockTransactionsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error)
+	BlockTransactionsIter(ctx context.Context, block int64, optParams *BlockTransactionsParams) *Iterator[Transaction]
+	BlockDetail(ctx context.Context, block int64) (BlockDetail, error)
+}
+
+// MarketAPI covers the /market endpoints.
+type MarketAPI interface {
+	PoolMarketList(ctx context.Context, optParams *PoolMarketListParams) ([]PoolMarket, error)
+	PoolMarketListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *PoolMarketListParams) ([]PoolMarket, error)
+	PoolMarketListIter(ctx context.Context, optParams *PoolMarketListParams) *Iterator[PoolMarket]
+	PoolMarketInfo(ctx context.Context, address string) (PoolMarketInfo, error)
+	PoolMarketVolume(ctx context.Context, address string, startTime, endTime string) (PoolMarketVolume, error)
+}
+
+// MonitorAPI covers the /monitor endpoints.
+type MonitorAPI interface {
+	APIUsage(ctx context.Context) (APIUsage, error)
+}
+
+var _ API = (*Client)(nil)
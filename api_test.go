@@ -0,0 +1,35 @@
+package go3s
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func countTransfers(ctx context.Context, api AccountAPI, address string) (int, error) {
+	transfers, err := api.AccountTransfers(ctx, address, nil)
+	return len(transfers), err
+}
+
+func TestMockClient(t *testing.T) {
+	m := &MockClient{}
+	if _, err := countTransfers(context.Background(), m, "addr"); !errors.Is(err, ErrNotMocked) {
+		t.Fatalf("err = %v, want ErrNotMocked", err)
+	}
+	it := m.TokenHoldersIter(context.Background(), "token", nil)
+	if it.Next() || !errors.Is(it.Err(), ErrNotMocked) {
+		t.Fatalf("iterator err = %v, want ErrNotMocked", it.Err())
+	}
+
+	m.AccountTransfersFunc = func(ctx context.Context, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
+		return []Transfer{{TransID: "1"}, {TransID: "2"}}, nil
+	}
+	n, err := countTransfers(context.Background(), m, "addr")
+	if err != nil || n != 2 {
+		t.Fatalf("n = %d, err = %v, want 2", n, err)
+	}
+	calls := m.Calls("AccountTransfers")
+	if len(calls) != 2 || calls[1][1] != "addr" {
+		t.Fatalf("calls = %v", calls)
+	}
+}
@@ -0,0 +1,74 @@
+package go3s
+
+import (
+	"context"
+	"sync"
+)
+
+// batchGetter gets the key of a batch and records its outcome as soon as it is done,
+// so results are not held back by slower keys before it. It always succeeds,
+// failures are recorded instead of failing the batch.
+type batchGetter[T any] struct {
+	sg     SimpleGetter[T]
+	key    string
+	record func(key string, d T, err error)
+}
+
+func (g *batchGetter[T]) URL() string {
+	return g.sg.URL()
+}
+
+func (g *batchGetter[T]) Do(ctx context.Context) (T, error) {
+	d, err := g.sg.Do(ctx)
+	g.record(g.key, d, err)
+	return *new(T), nil
+}
+
+// batchGet gets sg once for every distinct key, passed as param,
+// with at most maxConcurrency requests in flight.
+// It returns the results and the errors by key, and an error if the batch
+// itself failed, e.g. because ctx was cancelled. Keys never requested
+// are then in neither map.
+func batchGet[T any](ctx context.Context, sg SimpleGetter[T], param string, keys []string, maxConcurrency int64) (map[string]T, map[string]error, error) {
+	var (
+		mu      sync.Mutex
+		results = make(map[string]T, len(keys))
+		errs    = map[string]error{}
+	)
+	record := func(key string, d T, err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if err != nil {
+			errs[key] = err
+		} else {
+			results[key] = d
+		}
+	}
+	seen := make(map[string]struct{}, len(keys))
+	getters := make([]Getter[T], 0, len(keys))
+	for _, k := range keys {
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		g := sg
+		g.Params = cloneParams(sg.Params)
+		g.Params.Set(param, k)
+		getters = append(getters, &batchGetter[T]{sg: g, key: k, record: record})
+	}
+	cg := CcrtGetter[T]{
+		Getters:        getters,
+		MaxConcurrency: maxConcurrency,
+	}
+	if sg.Option != nil {
+		cg.Logger = sg.Option.Logger
+	}
+	_, err := cg.Do(ctx)
+	if err == nil {
+		// The keys cancelled along with ctx are recorded as failed.
+		err = ctx.Err()
+	}
+	mu.Lock()
+	defer mu.Unlock()
+	return results, errs, err
+}
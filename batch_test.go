@@ -0,0 +1,71 @@
+package go3s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestBatchTokenMeta(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		address := r.URL.Query().Get("address")
+		if address == "bad" {
+			w.Write([]byte(`{"success":false,"errors":{"code":1,"message":"bad"}}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"address":"` + address + `"}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"))
+	metas, errs, err := client.BatchTokenMeta(context.Background(), []string{"a", "bad", "b", "a"}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("calls = %d, want 3", n)
+	}
+	if len(metas) != 2 || metas["a"].Address != "a" || metas["b"].Address != "b" {
+		t.Fatalf("metas = %+v", metas)
+	}
+	var apiErr *APIError
+	if len(errs) != 1 || !errors.As(errs["bad"], &apiErr) {
+		t.Fatalf("errs = %v", errs)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, _, err := client.BatchTokenMeta(ctx, []string{"a", "b"}, 2); !errors.Is(err, context.Canceled) {
+		t.Fatalf("err = %v, want context.Canceled", err)
+	}
+}
+
+func TestBatchTokenMetaCancelled(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		address := r.URL.Query().Get("address")
+		if address == "slow" {
+			<-r.Context().Done()
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"address":"` + address + `"}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"))
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	metas, errs, err := client.BatchTokenMeta(ctx, []string{"slow", "fast"}, 2)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if metas["fast"].Address != "fast" {
+		t.Fatalf("metas = %+v, want fast done before slow", metas)
+	}
+	if errs["slow"] == nil {
+		t.Fatalf("errs = %v, want slow failed", errs)
+	}
+}
@@ -0,0 +1,192 @@
+package go3s
+
+import (
+	"container/list"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// CACHE_FOREVER is the TTL of responses that never expire.
+const CACHE_FOREVER time.Duration = 0
+
+// Cache stores response bodies, keyed by request URL.
+// The token travels in headers, so keys never hold credentials.
+type Cache interface {
+	// Get returns the body stored under key, ok is false if it is missing or expired.
+	Get(key string) (body []byte, ok bool)
+	// Set stores body under key for ttl, CACHE_FOREVER or less never expires.
+	Set(key string, body []byte, ttl time.Duration)
+}
+
+// CachePolicy maps endpoint paths, e.g. "/token/meta", to the TTL of their responses.
+// Endpoints missing from the policy are not cached.
+type CachePolicy map[string]time.Duration
+
+// DefaultCachePolicy caches immutable data forever
+// and data changing slowly for a short time.
+var DefaultCachePolicy = CachePolicy{
+	"/transaction/detail":  CACHE_FOREVER,
+	"/transaction/actions": CACHE_FOREVER,
+	"/block/detail":        CACHE_FOREVER,
+	"/token/meta":          5 * time.Minute,
+	"/account/detail":      time.Minute,
+	"/token/price":         10 * time.Second,
+}
+
+// ttl returns the TTL of path, ok is false if it is not cached.
+func (p CachePolicy) ttl(path string) (ttl time.Duration, ok bool) {
+	if p == nil {
+		p = DefaultCachePolicy
+	}
+	ttl, ok = p["/"+strings.Trim(path, "/")]
+	return
+}
+
+func expiry(ttl time.Duration) time.Time {
+	if ttl <= CACHE_FOREVER {
+		return time.Time{}
+	}
+	return time.Now().Add(ttl)
+}
+
+func expired(expires time.Time) bool {
+	return !expires.IsZero() && time.Now().After(expires)
+}
+
+// LRUCache is an in-memory Cache evicting the least recently used entries.
+type LRUCache struct {
+	maxEntries int
+
+	mu      sync.Mutex
+	entries *list.List
+	index   map[string]*list.Element
+}
+
+type lruEntry struct {
+	key     string
+	body    []byte
+	expires time.Time
+}
+
+// NewLRUCache creates an LRUCache holding at most maxEntries responses,
+// 0 or less means no limit.
+func NewLRUCache(maxEntries int) *LRUCache {
+	return &LRUCache{
+		maxEntries: maxEntries,
+		entries:    list.New(),
+		index:      map[string]*list.Element{},
+	}
+}
+
+func (c *LRUCache) Get(key string) ([]byte, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.index[key]
+	if !ok {
+		return nil, false
+	}
+	e := el.Value.(*lruEntry)
+	if expired(e.expires) {
+		c.entries.Remove(el)
+		delete(c.index, key)
+		return nil, false
+	}
+	c.entries.MoveToFront(el)
+	return e.body, true
+}
+
+func (c *LRUCache) Set(key string, body []byte, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e := &lruEntry{key: key, body: body, expires: expiry(ttl)}
+	if el, ok := c.index[key]; ok {
+		el.Value = e
+		c.entries.MoveToFront(el)
+		return
+	}
+	c.index[key] = c.entries.PushFront(e)
+	for c.maxEntries > 0 && c.entries.Len() > c.maxEntries {
+		el := c.entries.Back()
+		c.entries.Remove(el)
+		delete(c.index, el.Value.(*lruEntry).key)
+	}
+}
+
+// Len returns the number of entries, expired ones included.
+func (c *LRUCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.entries.Len()
+}
+
+// DiskCache is a Cache storing every response as a JSON file in Dir,
+// so responses survive restarts and can be shared by jobs.
+// Read and write failures are treated as misses.
+type DiskCache struct {
+	Dir string
+}
+
+type diskEntry struct {
+	Key     string          `json:"key"`
+	Expires time.Time       `json:"expires"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// NewDiskCache creates a DiskCache storing its files in dir,
+// which is created on the first Set.
+func NewDiskCache(dir string) *DiskCache {
+	return &DiskCache{Dir: dir}
+}
+
+func (c *DiskCache) Get(key string) ([]byte, bool) {
+	b, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return nil, false
+	}
+	var e diskEntry
+	if err := json.Unmarshal(b, &e); err != nil || e.Key != key {
+		return nil, false
+	}
+	if expired(e.Expires) {
+		os.Remove(c.path(key))
+		return nil, false
+	}
+	return e.Body, true
+}
+
+// Set writes the entry to a temporary file renamed over its file,
+// so concurrent readers never see a half written entry.
+func (c *DiskCache) Set(key string, body []byte, ttl time.Duration) {
+	b, err := json.Marshal(diskEntry{Key: key, Expires: expiry(ttl), Body: body})
+	if err != nil {
+		return
+	}
+	if err := os.MkdirAll(c.Dir, 0o755); err != nil {
+		return
+	}
+	f, err := os.CreateTemp(c.Dir, "*.tmp")
+	if err != nil {
+		return
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return
+	}
+	if err := f.Close(); err != nil {
+		return
+	}
+	os.Rename(f.Name(), c.path(key))
+}
+
+// path returns the file of key, named after its hash.
+func (c *DiskCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.Dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -0,0 +1,104 @@
+package go3s
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLRUCache(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", []byte("1"), CACHE_FOREVER)
+	c.Set("b", []byte("2"), CACHE_FOREVER)
+	c.Get("a")
+	c.Set("c", []byte("3"), CACHE_FOREVER)
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("least recently used entry b was not evicted")
+	}
+	for _, k := range []string{"a", "c"} {
+		if _, ok := c.Get(k); !ok {
+			t.Fatalf("entry %s was evicted", k)
+		}
+	}
+	c.Set("d", []byte("4"), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("d"); ok {
+		t.Fatal("expired entry d was returned")
+	}
+	if n := c.Len(); n != 1 {
+		t.Fatalf("len = %d, want 1", n)
+	}
+}
+
+func TestDiskCache(t *testing.T) {
+	dir := t.TempDir()
+	NewDiskCache(dir).Set("a", []byte(`{"success":true}`), CACHE_FOREVER)
+	c := NewDiskCache(dir)
+	body, ok := c.Get("a")
+	if !ok || string(body) != `{"success":true}` {
+		t.Fatalf("get a = %s, %v", body, ok)
+	}
+	if _, ok := c.Get("b"); ok {
+		t.Fatal("missing entry b was returned")
+	}
+	c.Set("c", []byte(`{}`), time.Millisecond)
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := c.Get("c"); ok {
+		t.Fatal("expired entry c was returned")
+	}
+}
+
+func TestClientCache(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		if r.URL.Query().Get("address") == "bad" {
+			w.Write([]byte(`{"success":false,"errors":{"code":1,"message":"bad"}}`))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"address":"` + r.URL.Query().Get("address") + `"}}`))
+	}))
+	defer srv.Close()
+	cache := NewLRUCache(10)
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"), WithCache(cache, nil))
+	ctx := context.Background()
+	for range 3 {
+		meta, err := client.TokenMeta(ctx, "a")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if meta.Address != "a" {
+			t.Fatalf("address = %s, want a", meta.Address)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("calls = %d, want 1", n)
+	}
+	if used := client.CUBudget().Used(); used != DEFAULT_CU_COST {
+		t.Fatalf("used = %d, want %d", used, DEFAULT_CU_COST)
+	}
+	if _, err := client.TokenMeta(ctx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("calls = %d, want 2", n)
+	}
+
+	// Failed responses are not cached.
+	client.TokenMeta(ctx, "bad")
+	client.TokenMeta(ctx, "bad")
+	if n := calls.Load(); n != 4 {
+		t.Fatalf("calls = %d, want 4", n)
+	}
+
+	// Endpoints missing from the policy are not cached.
+	client = NewClient(WithProBaseURL(srv.URL), WithToken("t"), WithCache(cache, CachePolicy{"/block/detail": CACHE_FOREVER}))
+	client.TokenMeta(ctx, "c")
+	client.TokenMeta(ctx, "c")
+	if n := calls.Load(); n != 6 {
+		t.Fatalf("calls = %d, want 6", n)
+	}
+}
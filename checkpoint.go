@@ -0,0 +1,143 @@
+package go3s
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+var ErrCheckpointMismatch = fmt.Errorf("solscan: checkpoint saved by another query")
+
+// CheckpointState is the progress of a paging run.
+type CheckpointState struct {
+	// Query identifies the run, its path and params without the paging one.
+	// Resuming a different query fails with ErrCheckpointMismatch.
+	Query string `json:"query,omitempty"`
+	// NextPage is the first page not completed yet, 0 if none was completed.
+	NextPage int64 `json:"next_page,omitempty"`
+	// Cursor is the last cursor completed by cursor based runs,
+	// e.g. the before hash of AccountTransactionsPagingQuery.
+	Cursor string `json:"cursor,omitempty"`
+	// Fetched is the number of items completed by cursor based runs,
+	// so a resumed run only fetches the rest of its TotalSize.
+	Fetched int64 `json:"fetched,omitempty"`
+	// Done reports whether the run completed, resuming it then returns no results.
+	Done bool `json:"done,omitempty"`
+}
+
+// Checkpoint persists the progress of a paging run, so a restarted run
+// resumes where the previous one stopped instead of starting from the first page.
+// A resumed run only returns the pages it fetched itself, so keep
+// the pages of earlier runs, e.g. by saving them in a PageHandler.
+type Checkpoint interface {
+	// Load returns the saved state, the zero state if nothing was saved.
+	Load() (CheckpointState, error)
+	// Save is called after every completed page, pages complete in order.
+	Save(state CheckpointState) error
+}
+
+// CheckpointError is returned by checkpointed runs that failed.
+// The results fetched before the failure are returned along with it.
+type CheckpointError struct {
+	State CheckpointState // the state saved last
+	Err   error
+}
+
+func (e *CheckpointError) Error() string {
+	if e.State.Cursor != "" {
+		return fmt.Sprintf("%s, resume from cursor %s", e.Err.Error(), e.State.Cursor)
+	}
+	return fmt.Sprintf("%s, resume from page %d", e.Err.Error(), e.State.NextPage)
+}
+
+func (e *CheckpointError) Unwrap() error {
+	return e.Err
+}
+
+// FileCheckpoint is a Checkpoint saving its state as JSON in a file.
+type FileCheckpoint struct {
+	Path string
+
+	mu sync.Mutex
+}
+
+// NewFileCheckpoint creates a FileCheckpoint saving to path.
+func NewFileCheckpoint(path string) *FileCheckpoint {
+	return &FileCheckpoint{Path: path}
+}
+
+func (c *FileCheckpoint) Load() (CheckpointState, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	var state CheckpointState
+	b, err := os.ReadFile(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return state, nil
+	}
+	if err != nil {
+		return state, err
+	}
+	err = json.Unmarshal(b, &state)
+	return state, err
+}
+
+// Save writes the state to a temporary file renamed over Path,
+// so a crash never leaves a half written checkpoint.
+func (c *FileCheckpoint) Save(state CheckpointState) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	b, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	f, err := os.CreateTemp(filepath.Dir(c.Path), filepath.Base(c.Path)+".tmp*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(f.Name())
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	return os.Rename(f.Name(), c.Path)
+}
+
+// Remove deletes the checkpoint file, so the next run starts over.
+func (c *FileCheckpoint) Remove() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	err := os.Remove(c.Path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	return err
+}
+
+// checkpointQuery returns the Query of a run of path with params, without the paging param.
+func checkpointQuery(path string, params url.Values, pagingParam string) string {
+	p := cloneParams(params)
+	p.Del(pagingParam)
+	return "/" + strings.Trim(path, "/") + "?" + p.Encode()
+}
+
+// loadCheckpoint loads the state of the run of query from cp.
+func loadCheckpoint(cp Checkpoint, query string) (CheckpointState, error) {
+	state, err := cp.Load()
+	if err != nil {
+		return state, fmt.Errorf("solscan: can not load checkpoint: %w", err)
+	}
+	if state.Query != "" && state.Query != query {
+		return state, fmt.Errorf("%w: %s, not %s", ErrCheckpointMismatch, state.Query, query)
+	}
+	state.Query = query
+	return state, nil
+}
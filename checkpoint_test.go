@@ -0,0 +1,93 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func TestFileCheckpoint(t *testing.T) {
+	cp := NewFileCheckpoint(filepath.Join(t.TempDir(), "job.json"))
+	state, err := cp.Load()
+	if err != nil || state != (CheckpointState{}) {
+		t.Fatalf("state = %+v, err = %v, want zero state", state, err)
+	}
+	want := CheckpointState{NextPage: 3, Cursor: "hash"}
+	if err := cp.Save(want); err != nil {
+		t.Fatal(err)
+	}
+	if state, err = cp.Load(); err != nil || state != want {
+		t.Fatalf("state = %+v, err = %v, want %+v", state, err, want)
+	}
+	if err := cp.Remove(); err != nil {
+		t.Fatal(err)
+	}
+	if state, _ = cp.Load(); state != (CheckpointState{}) {
+		t.Fatalf("state = %+v after Remove", state)
+	}
+}
+
+func TestPagingGetterCheckpoint(t *testing.T) {
+	var failing atomic.Bool
+	var calls atomic.Int64
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 3 && failing.Load() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := []Transfer{}
+		for i := (page - 1) * 10; i < page*10 && i < 45; i++ {
+			data = append(data, Transfer{TransID: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL))
+	cp := NewFileCheckpoint(filepath.Join(t.TempDir(), "job.json"))
+	resumable := client.WithCheckpoint(cp)
+	ctx := context.Background()
+	params := &AccountTransfersParams{PageSize: LargePageSize10}
+
+	transfers, err := resumable.AccountTransfersPagingQuery(ctx, 1, 0, 1, "addr", params)
+	var cpErr *CheckpointError
+	if !errors.As(err, &cpErr) || !errors.Is(err, Err400) {
+		t.Fatalf("err = %v, want a CheckpointError wrapping Err400", err)
+	}
+	if cpErr.State.NextPage != 3 || len(transfers) != 20 {
+		t.Fatalf("next page = %d, transfers = %d, want 3 and 20", cpErr.State.NextPage, len(transfers))
+	}
+
+	failing.Store(false)
+	transfers, err = resumable.AccountTransfersPagingQuery(ctx, 1, 0, 1, "addr", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 25 || transfers[0].TransID != "20" {
+		t.Fatalf("transfers = %d, want the 25 from page 3 on", len(transfers))
+	}
+	if state, _ := cp.Load(); !state.Done {
+		t.Fatalf("state = %+v, want done", state)
+	}
+
+	before := calls.Load()
+	transfers, err = resumable.AccountTransfersPagingQuery(ctx, 1, 0, 1, "addr", params)
+	if err != nil || len(transfers) != 0 || calls.Load() != before {
+		t.Fatalf("transfers = %d, err = %v, want nothing fetched again for a done run", len(transfers), err)
+	}
+	if _, err := resumable.AccountTransfersPagingQuery(ctx, 1, 0, 1, "other", params); !errors.Is(err, ErrCheckpointMismatch) {
+		t.Fatalf("err = %v, want ErrCheckpointMismatch", err)
+	}
+	transfers, err = client.AccountTransfersPagingQuery(ctx, 1, 0, 1, "other", params)
+	if err != nil || len(transfers) != 45 {
+		t.Fatalf("transfers = %d, err = %v, want 45 without a checkpoint", len(transfers), err)
+	}
+}
@@ -3,12 +3,13 @@ package go3s
 import (
 	"context"
 	"fmt"
-	"math"
+	"log/slog"
+	"net/http"
 	"net/url"
 	"os"
 	"strconv"
-	"time"
 
+	"golang.org/x/sync/singleflight"
 	"golang.org/x/time/rate"
 )
 
@@ -22,11 +23,33 @@ const (
 	V3_MAX_REQUESTS_PER_MINUTE = 2000
 )
 
+// Compute units charged per request by Solscan, see DefaultCUCosts.
+const (
+	DEFAULT_CU_COST = 100
+	EXPORT_CU_COST  = 300
+)
+
+// DEFAULT_LIMITER_BURST is how many requests a limiter lets through at once
+// before falling back to its sustained rate.
+const DEFAULT_LIMITER_BURST = 10
+
+// V2Limiter and V3Limiter are shared limiters for clients that intentionally
+// share one API key quota, e.g. NewClient(WithLimiter(V2Limiter)).
+// Clients created without WithLimiter get a limiter of their own.
 var (
-	V2Limiter = rate.NewLimiter(rate.Every(time.Minute), V2_MAX_REQUESTS_PER_MINUTE)
-	V3Limiter = rate.NewLimiter(rate.Every(time.Minute), V3_MAX_REQUESTS_PER_MINUTE)
+	V2Limiter = NewRequestsPerMinuteLimiter(V2_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST)
+	V3Limiter = NewRequestsPerMinuteLimiter(V3_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST)
 )
 
+// NewRequestsPerMinuteLimiter creates a limiter spreading rpm requests evenly over a minute
+// and allowing bursts of up to burst requests.
+func NewRequestsPerMinuteLimiter(rpm, burst int) *rate.Limiter {
+	if burst <= 0 {
+		burst = 1
+	}
+	return rate.NewLimiter(rate.Limit(float64(rpm)/60), burst)
+}
+
 type RespData[D any] struct {
 	Success bool `json:"success"`
 	Data    D    `json:"data"`
@@ -98,6 +121,13 @@ const (
 	LargePageSize100 LargePageSize = 100
 )
 
+// Page sizes accepted by endpoints using each page size enum.
+var (
+	tinyPageSizes  = []int64{12, 24, 36}
+	smallPageSizes = []int64{10, 20, 30, 40}
+	largePageSizes = []int64{10, 20, 30, 40, 60, 100}
+)
+
 type SortBy string
 
 const (
@@ -771,40 +801,130 @@ type NFTCollectionItem struct {
 }
 
 type Client struct {
-	limiter *rate.Limiter
-	headers map[string][]string
+	proBaseURL     string
+	publicBaseURL  string
+	limiter        Limiter
+	headers        map[string][]string
+	httpClient     *http.Client
+	logger         *slog.Logger
+	getterOption   *GetterOption
+	pagingOption   *GetterOption
+	cuBudget       *CUBudget
+	maxPages       int64
+	partialResults bool
+	cache          Cache
+	cachePolicy    CachePolicy
+	cuCosts        CUCosts
+	checkpoint     Checkpoint
+	flight         *singleflight.Group
+}
+
+// NewClient creates a Client configured by opts.
+// Without WithToken, the token is read from SOLSCAN_AUTH_TOKEN.
+// Without WithLimiter or WithRequestsPerMinute, the client gets its own limiter
+// allowing V2_MAX_REQUESTS_PER_MINUTE.
+func NewClient(opts ...Option) *Client {
+	c := &Client{
+		proBaseURL:    PRO_BASE_URL,
+		publicBaseURL: PUBLIC_BASE_URL,
+		limiter:       NewRequestsPerMinuteLimiter(V2_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST),
+		headers: map[string][]string{
+			"content-type": {"application/json"},
+		},
+		logger: logger,
+		flight: new(singleflight.Group),
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	if len(c.headers["token"]) == 0 || c.headers["token"][0] == "" {
+		c.headers["token"] = []string{os.Getenv("SOLSCAN_AUTH_TOKEN")}
+	}
+	if c.cuBudget == nil {
+		c.cuBudget = NewCUBudget(0, 0)
+	}
+	if c.limiter == nil {
+		c.limiter = NewRequestsPerMinuteLimiter(V2_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST)
+	}
+	c.getterOption = c.withDefaults(c.getterOption, defaultGetterOption)
+	c.pagingOption = c.withDefaults(c.pagingOption, defaultPagingGetterOption)
+	return c
+}
+
+func NewV2Client(auth_token string, opts ...Option) *Client {
+	return NewClient(append([]Option{WithToken(auth_token), WithRequestsPerMinute(V2_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST)}, opts...)...)
+}
+
+func NewV3Client(auth_token string, opts ...Option) *Client {
+	return NewClient(append([]Option{WithToken(auth_token), WithRequestsPerMinute(V3_MAX_REQUESTS_PER_MINUTE, DEFAULT_LIMITER_BURST)}, opts...)...)
 }
 
-func NewClient(auth_token string, limiter *rate.Limiter) *Client {
-	if auth_token == "" {
-		auth_token = os.Getenv("SOLSCAN_AUTH_TOKEN")
+// withDefaults returns a copy of opt, or of def if opt is nil,
+// that logs to the client logger, charges the client CU budget
+// at the client CU costs and uses the client cache unless it has its own.
+// Its identical concurrent requests are coalesced with those of the client.
+func (c *Client) withDefaults(opt, def *GetterOption) *GetterOption {
+	if opt == nil {
+		opt = def
 	}
-	if limiter == nil {
-		limiter = V2Limiter
+	o := *opt
+	if o.Logger == nil {
+		o.Logger = c.logger
 	}
-	return &Client{
-		limiter: limiter,
-		headers: map[string][]string{
-			"content-type": {"application/json"},
-			"token":        {auth_token},
-		},
+	if o.CUBudget == nil {
+		o.CUBudget = c.cuBudget
 	}
+	if o.CUCosts == nil {
+		o.CUCosts = c.cuCosts
+	}
+	if o.Cache == nil {
+		o.Cache = c.cache
+		o.CachePolicy = c.cachePolicy
+	}
+	o.flight = c.flight
+	return &o
 }
 
-func NewV2Client(auth_token string) *Client {
-	return NewClient(auth_token, V2Limiter)
+// WithCheckpoint returns a copy of c whose PagingQuery runs load and save
+// their progress in cp, e.g. c.WithCheckpoint(cp).AccountTransfersPagingQuery(...).
+// A checkpoint belongs to a single query, running another one with it
+// fails with ErrCheckpointMismatch.
+func (c *Client) WithCheckpoint(cp Checkpoint) *Client {
+	cc := *c
+	cc.checkpoint = cp
+	return &cc
 }
 
-func NewV3Client(auth_token string) *Client {
-	return NewClient(auth_token, V3Limiter)
+// Limiter returns the limiter requests wait on,
+// e.g. to read the current rate of an *AdaptiveLimiter.
+func (c *Client) Limiter() Limiter {
+	return c.limiter
+}
+
+// CUBudget returns the budget tracking the compute units spent by c.
+func (c *Client) CUBudget() *CUBudget {
+	return c.cuBudget
+}
+
+// ReconcileCUBudget fetches the account usage and reconciles the client budget with it.
+// Call it periodically when other processes share the API key.
+func (c *Client) ReconcileCUBudget(ctx context.Context) (APIUsage, error) {
+	usage, err := c.APIUsage(ctx)
+	if err != nil {
+		return usage, err
+	}
+	c.cuBudget.Reconcile(usage)
+	return usage, nil
 }
 
 func (c *Client) ChainInfo(ctx context.Context) (ChainInfo, error) {
 	sg := SimpleGetter[ChainInfo]{
-		BaseURL: PUBLIC_BASE_URL,
-		Path:    "chaininfo",
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.publicBaseURL,
+		Path:       "chaininfo",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -828,11 +948,13 @@ type AccountTransfersParams struct {
 func (c *Client) AccountTransfers(ctx context.Context, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
 	params := createParams(optParams, "address", address)
 	sg := SimpleGetter[[]Transfer]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/transfer",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/transfer",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -840,23 +962,84 @@ func (c *Client) AccountTransfers(ctx context.Context, address string, optParams
 func (c *Client) AccountTransfersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
 	params := createParams(optParams, "address", address)
 	g := PagingGetter[[]Transfer]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/transfer",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/transfer",
+		Params:       params,
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
+		PagingParams: &PagingParams[[]Transfer]{
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Transfer],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[Transfer](totalSize),
 		},
+	}
+	return g.Do(ctx)
+}
+
+func (c *Client) AccountTransfersIter(ctx context.Context, address string, optParams *AccountTransfersParams) *Iterator[Transfer] {
+	params := createParams(optParams, "address", address)
+	sg := SimpleGetter[[]Transfer]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/transfer",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[Transfer])
+}
+
+// AccountTransfersShardQuery fetches every item in optParams.BlockTimeRange as shards windows, see ShardGetter.
+func (c *Client) AccountTransfersShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
+	params := createParams(optParams, "address", address)
+	g := PagingGetter[[]Transfer]{
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/transfer",
+		Params:       params,
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]Transfer]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[Transfer](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[Transfer](totalSize),
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Transfer],
+			PageSizes:                largePageSizes,
 		},
 	}
+	sg, err := newSliceShardGetter(g, shards, maxConcurrency, func(t Transfer) int64 { return t.BlockTime }, TransferKey)
+	if err != nil {
+		return nil, err
+	}
+	return sg.Do(ctx)
+}
+
+// AccountTransfersStableQuery fetches up to totalSize transfers, 0 meaning all, newest first
+// while new transfers keep arriving, see StableGetter. A 0 upper bound of
+// optParams.BlockTimeRange, or no BlockTimeRange, is pinned to the current time.
+// Transfers are told apart by TransferKey, as the response has no instruction index.
+func (c *Client) AccountTransfersStableQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransfersParams) ([]Transfer, []BlockTimeGap, error) {
+	sg := SimpleGetter[[]Transfer]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/transfer",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	g, err := newSliceStableGetter(sg, largePageSizes, func(t Transfer) int64 { return t.BlockTime }, TransferKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	g.TotalSize = totalSize
 	return g.Do(ctx)
 }
 
@@ -870,11 +1053,13 @@ type AccountTokenAccountsParams struct {
 func (c *Client) AccountTokenAccounts(ctx context.Context, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error) {
 	params := createParams(optParams, "address", address)
 	sg := SimpleGetter[[]TokenAccount]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/token-accounts",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/token-accounts",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -882,26 +1067,42 @@ func (c *Client) AccountTokenAccounts(ctx context.Context, address string, optPa
 func (c *Client) AccountTokenAccountsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error) {
 	params := createParams(optParams, "address", address)
 	g := PagingGetter[[]TokenAccount]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/token-accounts",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/token-accounts",
+		Params:       params,
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]TokenAccount]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[TokenAccount](int64(SmallPageSize40)),
-			ResultsHandler:    CreateSliceResultsHandler[TokenAccount](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[TokenAccount],
+			PageSizes:                smallPageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[TokenAccount](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) AccountTokenAccountsIter(ctx context.Context, address string, optParams *AccountTokenAccountsParams) *Iterator[TokenAccount] {
+	params := createParams(optParams, "address", address)
+	sg := SimpleGetter[[]TokenAccount]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/token-accounts",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[TokenAccount])
+}
+
 type AccountDefiActivitiesParams struct {
 	ActivityType   ActivityType  `json:"activity_type,omitempty"`
 	FromAddress    string        `json:"from_address,omitempty"`
@@ -918,11 +1119,13 @@ type AccountDefiActivitiesParams struct {
 func (c *Client) AccountDefiActivities(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
 	params := createParams(optParams, "address", address)
 	sg := SimpleGetter[[]DefiActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/defi/activities",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/defi/activities",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -930,26 +1133,65 @@ func (c *Client) AccountDefiActivities(ctx context.Context, address string, optP
 func (c *Client) AccountDefiActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
 	params := createParams(optParams, "address", address)
 	g := PagingGetter[[]DefiActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/defi/activities",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/defi/activities",
+		Params:       params,
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]DefiActivity]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[DefiActivity](int64(SmallPageSize40)),
-			ResultsHandler:    CreateSliceResultsHandler[DefiActivity](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[DefiActivity],
+			PageSizes:                smallPageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[DefiActivity](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) AccountDefiActivitiesIter(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) *Iterator[DefiActivity] {
+	params := createParams(optParams, "address", address)
+	sg := SimpleGetter[[]DefiActivity]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/defi/activities",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[DefiActivity])
+}
+
+// AccountDefiActivitiesShardQuery fetches every item in optParams.BlockTimeRange as shards windows, see ShardGetter.
+func (c *Client) AccountDefiActivitiesShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
+	params := createParams(optParams, "address", address)
+	g := PagingGetter[[]DefiActivity]{
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/defi/activities",
+		Params:       params,
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
+		PagingParams: &PagingParams[[]DefiActivity]{
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[DefiActivity],
+			PageSizes:                smallPageSizes,
+		},
+	}
+	sg, err := newSliceShardGetter(g, shards, maxConcurrency, func(t DefiActivity) int64 { return t.BlockTime }, DefiActivityKey)
+	if err != nil {
+		return nil, err
+	}
+	return sg.Do(ctx)
+}
+
 type AccountBalanceChangesParams struct {
 	Token          string        `json:"token,omitempty"`
 	AmountRange    []int64       `json:"amount,omitempty"`
@@ -964,37 +1206,76 @@ type AccountBalanceChangesParams struct {
 
 func (c *Client) AccountBalanceChanges(ctx context.Context, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
 	sg := SimpleGetter[[]AccountChangeActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/balance_change",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/balance_change",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) AccountBalanceChangesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
 	g := PagingGetter[[]AccountChangeActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/balance_change",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/balance_change",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]AccountChangeActivity]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[AccountChangeActivity](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[AccountChangeActivity](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[AccountChangeActivity],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[AccountChangeActivity](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) AccountBalanceChangesIter(ctx context.Context, address string, optParams *AccountBalanceChangesParams) *Iterator[AccountChangeActivity] {
+	sg := SimpleGetter[[]AccountChangeActivity]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/balance_change",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[AccountChangeActivity])
+}
+
+// AccountBalanceChangesShardQuery fetches every item in optParams.BlockTimeRange as shards windows, see ShardGetter.
+func (c *Client) AccountBalanceChangesShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
+	g := PagingGetter[[]AccountChangeActivity]{
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/balance_change",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
+		PagingParams: &PagingParams[[]AccountChangeActivity]{
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[AccountChangeActivity],
+			PageSizes:                largePageSizes,
+		},
+	}
+	sg, err := newSliceShardGetter(g, shards, maxConcurrency, func(t AccountChangeActivity) int64 { return t.BlockTime }, BalanceChangeKey)
+	if err != nil {
+		return nil, err
+	}
+	return sg.Do(ctx)
+}
+
 type AccountTransactionsParams struct {
 	Before string        `json:"before,omitempty"`
 	Limit  SmallPageSize `json:"limit" default:"40"`
@@ -1002,42 +1283,46 @@ type AccountTransactionsParams struct {
 
 func (c *Client) AccountTransactions(ctx context.Context, address string, optParams *AccountTransactionsParams) ([]Transaction, error) {
 	sg := SimpleGetter[[]Transaction]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/transactions",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/transactions",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) AccountTransactionsPagingQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransactionsParams) ([]Transaction, error) {
-	txs := []Transaction{}
-	if optParams == nil {
-		optParams = &AccountTransactionsParams{}
-	}
-	before := optParams.Before
-	g := PagingGetter[[]Transaction]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/transactions",
-		Headers: c.headers,
-		Limiter: c.limiter,
-	}
-	pageNum := int(math.Ceil(float64(totalSize) / float64(SmallPageSize40)))
-	for i := 0; i < pageNum; i++ {
-		optParams.Before = before
-		g.Params = createParams(optParams, "address", address)
-		newTxs, err := g.Do(ctx)
-		if err != nil {
-			return nil, err
-		}
-		txs = append(txs, newTxs...)
-		if len(newTxs) < int(SmallPageSize40) {
-			break
-		}
-		before = newTxs[len(newTxs)-1].TxHash
-	}
-	return txs, nil
+	g := c.accountTransactionsCursorGetter(address, optParams)
+	g.TotalSize = totalSize
+	g.MaxPages = c.maxPages
+	g.Checkpoint = c.checkpoint
+	return g.Do(ctx)
+}
+
+func (c *Client) AccountTransactionsIter(ctx context.Context, address string, optParams *AccountTransactionsParams) *Iterator[Transaction] {
+	g := c.accountTransactionsCursorGetter(address, optParams)
+	return g.Iter(ctx)
+}
+
+func (c *Client) accountTransactionsCursorGetter(address string, optParams *AccountTransactionsParams) *CursorGetter[Transaction] {
+	return &CursorGetter[Transaction]{
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/transactions",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
+		CursorParam:  "before",
+		LimitParam:   "limit",
+		PageSizes:    smallPageSizes,
+		Cursor: func(tx Transaction) string {
+			return tx.TxHash
+		},
+	}
 }
 
 type AccountStakesParams struct {
@@ -1047,51 +1332,90 @@ type AccountStakesParams struct {
 
 func (c *Client) AccountStakes(ctx context.Context, address string, optParams *AccountStakesParams) ([]AccountStake, error) {
 	sg := SimpleGetter[[]AccountStake]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/stake",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/stake",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) AccountStakesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *AccountStakesParams) ([]AccountStake, error) {
 	g := PagingGetter[[]AccountStake]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/stake",
-		Headers: c.headers,
-		Limiter: c.limiter,
-		Params:  createParams(optParams, "address", address),
+		BaseURL:      c.proBaseURL,
+		Path:         "/account/stake",
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		Params:       createParams(optParams, "address", address),
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]AccountStake]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[AccountStake](int64(SmallPageSize40)),
-			ResultsHandler:    CreateSliceResultsHandler[AccountStake](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[AccountStake],
+			PageSizes:                smallPageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[AccountStake](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) AccountStakesIter(ctx context.Context, address string, optParams *AccountStakesParams) *Iterator[AccountStake] {
+	sg := SimpleGetter[[]AccountStake]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/stake",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[AccountStake])
+}
+
 func (c *Client) AccountDetail(ctx context.Context, address string) (AccountDetail, error) {
 	sg := SimpleGetter[AccountDetail]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/account/detail",
-		Params:  url.Values{"address": {address}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/detail",
+		Params:     url.Values{"address": {address}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
+// BatchAccountDetail gets the detail of every account address, see BatchTxDetail.
+func (c *Client) BatchAccountDetail(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]AccountDetail, map[string]error, error) {
+	sg := SimpleGetter[AccountDetail]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/account/detail",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
+	}
+	return batchGet(ctx, sg, "address", addresses, maxConcurrency)
+}
+
 func (c *Client) AccountRewardsExport(ctx context.Context, address string, timeFrom, timeTo int64) ([]byte, error) {
 	sg := SimpleGetter[[]byte]{
-		BaseURL:           PRO_BASE_URL,
+		BaseURL:           c.proBaseURL,
 		Path:              "/account/reward/export",
 		Params:            url.Values{"address": {address}, "time_from": {strconv.FormatInt(timeFrom, 10)}, "time_to": {strconv.FormatInt(timeTo, 10)}},
 		Headers:           c.headers,
 		Limiter:           c.limiter,
+		HTTPClient:        c.httpClient,
+		Option:            c.getterOption,
+		RespStatusHandler: ExportRespStatusHandler,
 		RespBodyUnmarshal: ExportBodyUnmarshal,
 	}
 	return sg.Do(ctx)
@@ -1112,11 +1436,14 @@ type AccountTransfersExportParams struct {
 
 func (c *Client) AccountTransfersExport(ctx context.Context, address string, optParams *AccountTransfersExportParams) ([]byte, error) {
 	sg := SimpleGetter[[]byte]{
-		BaseURL:           PRO_BASE_URL,
+		BaseURL:           c.proBaseURL,
 		Path:              "/account/transfer/export",
 		Params:            createParams(optParams, "address", address),
 		Headers:           c.headers,
 		Limiter:           c.limiter,
+		HTTPClient:        c.httpClient,
+		Option:            c.getterOption,
+		RespStatusHandler: ExportRespStatusHandler,
 		RespBodyUnmarshal: ExportBodyUnmarshal,
 	}
 	return sg.Do(ctx)
@@ -1137,37 +1464,76 @@ type TokenTransfersParams struct {
 
 func (c *Client) TokenTransfers(ctx context.Context, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
 	sg := SimpleGetter[[]Transfer]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/transfer",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/transfer",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TokenTransfersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
 	g := PagingGetter[[]Transfer]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/transfer",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/transfer",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]Transfer]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[Transfer](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[Transfer](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Transfer],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[Transfer](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) TokenTransfersIter(ctx context.Context, address string, optParams *TokenTransfersParams) *Iterator[Transfer] {
+	sg := SimpleGetter[[]Transfer]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/transfer",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[Transfer])
+}
+
+// TokenTransfersShardQuery fetches every item in optParams.BlockTimeRange as shards windows, see ShardGetter.
+func (c *Client) TokenTransfersShardQuery(ctx context.Context, shards, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
+	g := PagingGetter[[]Transfer]{
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/transfer",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
+		PagingParams: &PagingParams[[]Transfer]{
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Transfer],
+			PageSizes:                largePageSizes,
+		},
+	}
+	sg, err := newSliceShardGetter(g, shards, maxConcurrency, func(t Transfer) int64 { return t.BlockTime }, TransferKey)
+	if err != nil {
+		return nil, err
+	}
+	return sg.Do(ctx)
+}
+
 type TokenDefiActivitiesParams struct {
 	FromAddress    string        `json:"from_address,omitempty"`
 	Platform       []string      `json:"platform,omitempty"`
@@ -1183,37 +1549,54 @@ type TokenDefiActivitiesParams struct {
 
 func (c *Client) TokenDefiActivities(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error) {
 	sg := SimpleGetter[[]DefiActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/defi/activities",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/defi/activities",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TokenDefiActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error) {
 	g := PagingGetter[[]DefiActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/defi/activities",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/defi/activities",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]DefiActivity]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[DefiActivity](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[DefiActivity](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[DefiActivity],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[DefiActivity](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) TokenDefiActivitiesIter(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) *Iterator[DefiActivity] {
+	sg := SimpleGetter[[]DefiActivity]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/defi/activities",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[DefiActivity])
+}
+
 type TokenMarketsParams struct {
 	Program  string        `json:"program,omitempty"`
 	Page     int64         `json:"page" default:"1"`
@@ -1223,37 +1606,54 @@ type TokenMarketsParams struct {
 
 func (c *Client) TokenMarkets(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) ([]Market, error) {
 	sg := SimpleGetter[[]Market]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/markets",
-		Params:  createParams(optParams, "token[]", token_pair[0], "token[]", token_pair[1]),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/markets",
+		Params:     createParams(optParams, "token[]", token_pair[0], "token[]", token_pair[1]),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TokenMarketsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, token_pair []string, optParams *TokenMarketsParams) ([]Market, error) {
 	g := PagingGetter[[]Market]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/markets",
-		Params:  createParams(optParams, "token[]", token_pair[0], "token[]", token_pair[1]),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/markets",
+		Params:       createParams(optParams, "token[]", token_pair[0], "token[]", token_pair[1]),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]Market]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[Market](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[Market](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Market],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[Market](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) TokenMarketsIter(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) *Iterator[Market] {
+	sg := SimpleGetter[[]Market]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/markets",
+		Params:     createParams(optParams, "token[]", token_pair[0], "token[]", token_pair[1]),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[Market])
+}
+
 type TokenListParams struct {
 	SortBy    TokenSortBy   `json:"sort_by" default:"price"`
 	SortOrder SortOrder     `json:"sort_order" default:"desc"`
@@ -1263,44 +1663,63 @@ type TokenListParams struct {
 
 func (c *Client) TokenList(ctx context.Context, optParams *TokenListParams) ([]Token, error) {
 	sg := SimpleGetter[[]Token]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/list",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/list",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TokenListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *TokenListParams) ([]Token, error) {
 	g := PagingGetter[[]Token]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/list",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/list",
+		Params:       createParams(optParams),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]Token]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[Token](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[Token](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[Token],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[Token](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) TokenListIter(ctx context.Context, optParams *TokenListParams) *Iterator[Token] {
+	sg := SimpleGetter[[]Token]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/list",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[Token])
+}
+
 func (c *Client) TokenTrending(ctx context.Context, limit int64) ([]Token, error) {
 	g := SimpleGetter[[]Token]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/trending",
-		Params:  url.Values{"limit": {strconv.FormatInt(limit, 10)}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/trending",
+		Params:     url.Values{"limit": {strconv.FormatInt(limit, 10)}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return g.Do(ctx)
 }
@@ -1311,11 +1730,13 @@ func (c *Client) TokenPrice(ctx context.Context, address, startTime, endTime str
 	params.Add("time[]", startTime)
 	params.Add("time[]", endTime)
 	sg := SimpleGetter[[]TokenPrice]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/price",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/price",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -1329,54 +1750,89 @@ type TokenHoldersParams struct {
 
 func (c *Client) TokenHolders(ctx context.Context, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error) {
 	sg := SimpleGetter[RespDataWithTotal[TokenHolder]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/holders",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/holders",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TokenHoldersPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error) {
 	g := PagingGetter[RespDataWithTotal[TokenHolder]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/holders",
-		Params:  createParams(optParams, "address", address),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/token/holders",
+		Params:       createParams(optParams, "address", address),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[RespDataWithTotal[TokenHolder]]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateWithTotalItemsFinishChecker[TokenHolder](int64(SmallPageSize40)),
-			ResultsHandler:    CreateWithTotalItemsResultsHandler[TokenHolder](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateWithTotalItemsFinishChecker[TokenHolder],
+			PageSizes:                smallPageSizes,
+			ResultsHandler:           CreateWithTotalItemsResultsHandler[TokenHolder](totalSize),
+			TotalExtractor:           ExtractTotal[TokenHolder],
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) TokenHoldersIter(ctx context.Context, address string, optParams *TokenHoldersParams) *Iterator[TokenHolder] {
+	sg := SimpleGetter[RespDataWithTotal[TokenHolder]]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/holders",
+		Params:     createParams(optParams, "address", address),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, withTotalItems[TokenHolder])
+}
+
 func (c *Client) TokenMeta(ctx context.Context, address string) (TokenMeta, error) {
 	sg := SimpleGetter[TokenMeta]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/meta",
-		Params:  url.Values{"address": {address}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/meta",
+		Params:     url.Values{"address": {address}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
+// BatchTokenMeta gets the meta of every token address, see BatchTxDetail.
+func (c *Client) BatchTokenMeta(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]TokenMeta, map[string]error, error) {
+	sg := SimpleGetter[TokenMeta]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/meta",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
+	}
+	return batchGet(ctx, sg, "address", addresses, maxConcurrency)
+}
+
 func (c *Client) TokenTop(ctx context.Context) ([]TokenTop, error) {
 	sg := SimpleGetter[[]TokenTop]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/token/top",
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/token/top",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -1389,37 +1845,55 @@ type NFTNewsParams struct {
 
 func (c *Client) NFTNews(ctx context.Context, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error) {
 	sg := SimpleGetter[RespDataWithTotal[NFTInfo]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/news",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/news",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) NFTNewsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error) {
 	g := PagingGetter[RespDataWithTotal[NFTInfo]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/news",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/nft/news",
+		Params:       createParams(optParams),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[RespDataWithTotal[NFTInfo]]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateWithTotalDataFinishChecker[NFTInfo](int64(TinyPageSize36)),
-			ResultsHandler:    CreateWithTotalDataResultsHandler[NFTInfo](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateWithTotalDataFinishChecker[NFTInfo],
+			PageSizes:                tinyPageSizes,
+			ResultsHandler:           CreateWithTotalDataResultsHandler[NFTInfo](totalSize),
+			TotalExtractor:           ExtractTotal[NFTInfo],
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) NFTNewsIter(ctx context.Context, optParams *NFTNewsParams) *Iterator[NFTInfo] {
+	sg := SimpleGetter[RespDataWithTotal[NFTInfo]]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/news",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, withTotalData[NFTInfo])
+}
+
 type NFTActivitiesParams struct {
 	FromAddress    string          `json:"from,omitempty"`
 	ToAddress      string          `json:"to,omitempty"`
@@ -1436,37 +1910,54 @@ type NFTActivitiesParams struct {
 
 func (c *Client) NFTActivities(ctx context.Context, optParams *NFTActivitiesParams) ([]NFTActivity, error) {
 	sg := SimpleGetter[[]NFTActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/activities",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/activities",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) NFTActivitiesPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTActivitiesParams) ([]NFTActivity, error) {
 	g := PagingGetter[[]NFTActivity]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/activities",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/nft/activities",
+		Params:       createParams(optParams),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]NFTActivity]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[NFTActivity](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[NFTActivity](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[NFTActivity],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[NFTActivity](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) NFTActivitiesIter(ctx context.Context, optParams *NFTActivitiesParams) *Iterator[NFTActivity] {
+	sg := SimpleGetter[[]NFTActivity]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/activities",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[NFTActivity])
+}
+
 type NFTCollectionListParams struct {
 	Collection string              `json:"collection,omitempty"`
 	SortBy     NFTCollectionSortBy `json:"sort_by" default:"floor_price"`
@@ -1477,37 +1968,54 @@ type NFTCollectionListParams struct {
 
 func (c *Client) NFTCollectionList(ctx context.Context, optParams *NFTCollectionListParams) ([]NFTCollection, error) {
 	sg := SimpleGetter[[]NFTCollection]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/collection/lists",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/collection/lists",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) NFTCollectionListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *NFTCollectionListParams) ([]NFTCollection, error) {
 	g := PagingGetter[[]NFTCollection]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/collection/lists",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/nft/collection/lists",
+		Params:       createParams(optParams),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]NFTCollection]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[NFTCollection](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[NFTCollection](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[NFTCollection],
+			PageSizes:                smallPageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[NFTCollection](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) NFTCollectionListIter(ctx context.Context, optParams *NFTCollectionListParams) *Iterator[NFTCollection] {
+	sg := SimpleGetter[[]NFTCollection]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/collection/lists",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[NFTCollection])
+}
+
 type NFTCollectionItemsParams struct {
 	SortBy   NFTCollectionItemSortBy `json:"sort_by" default:"last_trade"`
 	Page     int64                   `json:"page" default:"1"`
@@ -1516,37 +2024,54 @@ type NFTCollectionItemsParams struct {
 
 func (c *Client) NFTCollectionItems(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error) {
 	sg := SimpleGetter[[]NFTCollectionItem]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/collection/items",
-		Params:  createParams(optParams, "collection", collection),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/collection/items",
+		Params:     createParams(optParams, "collection", collection),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) NFTCollectionItemsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error) {
 	g := PagingGetter[[]NFTCollectionItem]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/nft/collection/items",
-		Params:  createParams(optParams, "collection", collection),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/nft/collection/items",
+		Params:       createParams(optParams, "collection", collection),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]NFTCollectionItem]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[NFTCollectionItem](int64(TinyPageSize36)),
-			ResultsHandler:    CreateSliceResultsHandler[NFTCollectionItem](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[NFTCollectionItem],
+			PageSizes:                tinyPageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[NFTCollectionItem](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) NFTCollectionItemsIter(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) *Iterator[NFTCollectionItem] {
+	sg := SimpleGetter[[]NFTCollectionItem]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/nft/collection/items",
+		Params:     createParams(optParams, "collection", collection),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[NFTCollectionItem])
+}
+
 type TxLastParams struct {
 	Limit  LargePageSize `json:"limit" default:"100"`
 	Filter TxFilter      `json:"filter" default:"all"`
@@ -1554,44 +2079,82 @@ type TxLastParams struct {
 
 func (c *Client) TxLast(ctx context.Context, optParams *TxLastParams) ([]Transaction, error) {
 	sg := SimpleGetter[[]Transaction]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/transaction/last",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/transaction/last",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) TxDetail(ctx context.Context, tx string) (TransactionDetail, error) {
 	sg := SimpleGetter[TransactionDetail]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/transaction/detail",
-		Params:  url.Values{"tx": {tx}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/transaction/detail",
+		Params:     url.Values{"tx": {tx}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
+// BatchTxDetail gets the detail of every tx, with at most maxConcurrency requests
+// in flight through the client limiter. The txs that failed are listed in the error map
+// instead of failing the batch. The error is only set if the batch itself failed,
+// e.g. because ctx was cancelled. The results and errors of the txs done so far
+// are then returned along with it, txs never requested are in neither map.
+func (c *Client) BatchTxDetail(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionDetail, map[string]error, error) {
+	sg := SimpleGetter[TransactionDetail]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/transaction/detail",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
+	}
+	return batchGet(ctx, sg, "tx", txs, maxConcurrency)
+}
+
 func (c *Client) TxActions(ctx context.Context, tx string) (TransactionAction, error) {
 	sg := SimpleGetter[TransactionAction]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/transaction/actions",
-		Params:  url.Values{"tx": {tx}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/transaction/actions",
+		Params:     url.Values{"tx": {tx}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
+// BatchTxActions gets the actions of every tx, see BatchTxDetail.
+func (c *Client) BatchTxActions(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionAction, map[string]error, error) {
+	sg := SimpleGetter[TransactionAction]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/transaction/actions",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
+	}
+	return batchGet(ctx, sg, "tx", txs, maxConcurrency)
+}
+
 func (c *Client) BlocksLast(ctx context.Context, limit LargePageSize) ([]BlockDetail, error) {
 	sg := SimpleGetter[[]BlockDetail]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/block/last",
-		Params:  url.Values{"limit": {strconv.FormatInt(int64(limit), 10)}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/block/last",
+		Params:     url.Values{"limit": {strconv.FormatInt(int64(limit), 10)}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -1603,44 +2166,64 @@ type BlockTransactionsParams struct {
 
 func (c *Client) BlockTransactions(ctx context.Context, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error) {
 	sg := SimpleGetter[RespDataWithTotal[Transaction]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/block/transactions",
-		Params:  createParams(optParams, "block", strconv.FormatInt(block, 10)),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/block/transactions",
+		Params:     createParams(optParams, "block", strconv.FormatInt(block, 10)),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) BlockTransactionsPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error) {
 	g := PagingGetter[RespDataWithTotal[Transaction]]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/block/transactions",
-		Params:  createParams(optParams, "block", strconv.FormatInt(block, 10)),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/block/transactions",
+		Params:       createParams(optParams, "block", strconv.FormatInt(block, 10)),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[RespDataWithTotal[Transaction]]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateWithTotalTransactionsDataFinishChecker(int64(LargePageSize100)),
-			ResultsHandler:    CreateWithTotalTransactionsResultsHandler(totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateWithTotalTransactionsDataFinishChecker,
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateWithTotalTransactionsResultsHandler(totalSize),
+			TotalExtractor:           ExtractTotal[Transaction],
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) BlockTransactionsIter(ctx context.Context, block int64, optParams *BlockTransactionsParams) *Iterator[Transaction] {
+	sg := SimpleGetter[RespDataWithTotal[Transaction]]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/block/transactions",
+		Params:     createParams(optParams, "block", strconv.FormatInt(block, 10)),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, withTotalTransactions)
+}
+
 func (c *Client) BlockDetail(ctx context.Context, block int64) (BlockDetail, error) {
 	sg := SimpleGetter[BlockDetail]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/block/detail",
-		Params:  url.Values{"block": {strconv.FormatInt(block, 10)}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/block/detail",
+		Params:     url.Values{"block": {strconv.FormatInt(block, 10)}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -1655,44 +2238,63 @@ type PoolMarketListParams struct {
 
 func (c *Client) PoolMarketList(ctx context.Context, optParams *PoolMarketListParams) ([]PoolMarket, error) {
 	sg := SimpleGetter[[]PoolMarket]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/market/list",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/market/list",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) PoolMarketListPagingQuery(ctx context.Context, startPage, totalSize, maxConcurrency int64, optParams *PoolMarketListParams) ([]PoolMarket, error) {
 	g := PagingGetter[[]PoolMarket]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/market/list",
-		Params:  createParams(optParams),
-		Headers: c.headers,
-		Limiter: c.limiter,
-		GetterOption: &GetterOption{
-			RetryInterval: time.Second,
-			MaxRetries:    100,
-		},
+		BaseURL:      c.proBaseURL,
+		Path:         "/market/list",
+		Params:       createParams(optParams),
+		Headers:      c.headers,
+		Limiter:      c.limiter,
+		HTTPClient:   c.httpClient,
+		GetterOption: c.pagingOption,
 		PagingParams: &PagingParams[[]PoolMarket]{
-			StartPage:         startPage,
-			TotalSize:         totalSize,
-			MaxConcurrency:    maxConcurrency,
-			DataFinishChecker: CreateSliceDataFinishChecker[PoolMarket](int64(LargePageSize100)),
-			ResultsHandler:    CreateSliceResultsHandler[PoolMarket](totalSize),
+			StartPage:                startPage,
+			TotalSize:                totalSize,
+			MaxPages:                 c.maxPages,
+			PartialResults:           c.partialResults,
+			Checkpoint:               c.checkpoint,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: CreateSliceDataFinishChecker[PoolMarket],
+			PageSizes:                largePageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[PoolMarket](totalSize),
 		},
 	}
 	return g.Do(ctx)
 }
 
+func (c *Client) PoolMarketListIter(ctx context.Context, optParams *PoolMarketListParams) *Iterator[PoolMarket] {
+	sg := SimpleGetter[[]PoolMarket]{
+		BaseURL:    c.proBaseURL,
+		Path:       "/market/list",
+		Params:     createParams(optParams),
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.pagingOption,
+	}
+	return newPageIterator(ctx, sg, sliceItems[PoolMarket])
+}
+
 func (c *Client) PoolMarketInfo(ctx context.Context, address string) (PoolMarketInfo, error) {
 	sg := SimpleGetter[PoolMarketInfo]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/market/info",
-		Params:  url.Values{"address": {address}},
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/market/info",
+		Params:     url.Values{"address": {address}},
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
@@ -1703,21 +2305,25 @@ func (c *Client) PoolMarketVolume(ctx context.Context, address string, startTime
 	params.Add("time[]", startTime)
 	params.Add("time[]", endTime)
 	sg := SimpleGetter[PoolMarketVolume]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/market/volume",
-		Params:  params,
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/market/volume",
+		Params:     params,
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
 
 func (c *Client) APIUsage(ctx context.Context) (APIUsage, error) {
 	sg := SimpleGetter[APIUsage]{
-		BaseURL: PRO_BASE_URL,
-		Path:    "/monitor/usage",
-		Headers: c.headers,
-		Limiter: c.limiter,
+		BaseURL:    c.proBaseURL,
+		Path:       "/monitor/usage",
+		Headers:    c.headers,
+		Limiter:    c.limiter,
+		HTTPClient: c.httpClient,
+		Option:     c.getterOption,
 	}
 	return sg.Do(ctx)
 }
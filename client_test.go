@@ -1,321 +1,517 @@
-package go3s
+package go3s_test
 
 import (
 	"context"
-	"fmt"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"strings"
 	"testing"
+	"time"
+
+	"github.com/dwdwow/go3s"
+	"github.com/dwdwow/go3s/go3stest"
+	"github.com/dwdwow/go3s/replay"
+	"golang.org/x/time/rate"
+)
+
+const (
+	testAccount       = "3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5"
+	testToken         = "HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC"
+	testTx            = "3uf5w7XnMBd4xZTRTqSzi1L9S1QBFCMxAutTd4vCAdPWnjf5b815Ng2GfQwVRf4qHxHDDFWHT6tndHSD88HQkbSk"
+	testBlock   int64 = 315989642
 )
 
+// newTestClient creates a client replaying the responses recorded for the test
+// in testdata/replay, see package replay. Run with SOLSCAN_RECORD=1 and SOLSCAN_AUTH_TOKEN
+// to record them. Without recorded responses, the client calls a go3stest server
+// seeded by seed, which may be nil.
+func newTestClient(t *testing.T, seed func(s *go3stest.Server)) *go3s.Client {
+	t.Helper()
+	dir := filepath.Join("testdata", "replay", t.Name())
+	if !replay.Recording() && !replay.Exists(dir) {
+		srv := go3stest.NewServer()
+		t.Cleanup(srv.Close)
+		if seed != nil {
+			seed(srv)
+		}
+		return srv.Client()
+	}
+	opts := []go3s.Option{go3s.WithTransport(replay.New(dir))}
+	if !replay.Recording() {
+		opts = append(opts,
+			go3s.WithLimiter(rate.NewLimiter(rate.Inf, 1)),
+			go3s.WithPagingRetryPolicy(&go3s.GetterOption{
+				RetryInterval: time.Millisecond,
+				MaxRetries:    10,
+				ShouldRetry: func(err error, resp *http.Response) bool {
+					return !errors.Is(err, replay.ErrNoFixture) && go3s.DefaultShouldRetry(err, resp)
+				},
+			}))
+	}
+	return go3s.NewV2Client("", opts...)
+}
+
+// newTestTransfers returns n transfers, newest first.
+func newTestTransfers(n int) []go3s.Transfer {
+	transfers := make([]go3s.Transfer, n)
+	for i := range transfers {
+		transfers[i] = go3s.Transfer{TransID: strconv.Itoa(i), BlockTime: int64(n - i)}
+	}
+	return transfers
+}
+
+// newTestItems returns n items built by item.
+func newTestItems[T any](n int, item func(i int) T) []T {
+	items := make([]T, n)
+	for i := range items {
+		items[i] = item(i)
+	}
+	return items
+}
+
+// newTestTransactions returns n transactions, newest first.
+func newTestTransactions(n int) []go3s.Transaction {
+	txs := make([]go3s.Transaction, n)
+	for i := range txs {
+		txs[i] = go3s.Transaction{TxHash: strconv.Itoa(i), BlockTime: int64(n - i)}
+	}
+	return txs
+}
+
 func TestChainInfo(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/chaininfo", "", go3s.ChainInfo{BlockHeight: 1, CurrentEpoch: 1})
+	})
 	chainInfo, err := client.ChainInfo(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", chainInfo)
+	if chainInfo.BlockHeight == 0 || chainInfo.CurrentEpoch == 0 {
+		t.Fatalf("chain info = %+v", chainInfo)
+	}
 }
 
 func TestAccountTransfers(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddAccountTransfers(testAccount, newTestTransfers(3)...)
+	})
 	transfers, err := client.AccountTransfers(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	// fmt.Printf("%+v\n", activity)
-	fmt.Println(len(transfers))
+	if len(transfers) != 3 {
+		t.Fatalf("transfers = %d, want 3", len(transfers))
+	}
+	for i, tr := range transfers {
+		if tr.TransID == "" || i > 0 && tr.BlockTime > transfers[i-1].BlockTime {
+			t.Fatalf("transfer %d = %+v, want a trans_id, newest first", i, tr)
+		}
+	}
 }
 
 func TestAccountTransfersPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddAccountTransfers(testAccount, newTestTransfers(250)...)
+	})
 	transfers, err := client.AccountTransfersPagingQuery(
 		context.Background(),
 		1,
 		588,
 		1,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transfers))
+	if len(transfers) != 250 || transfers[0].TransID != "0" || transfers[249].TransID != "249" {
+		t.Fatalf("transfers = %d, want 250 newest first", len(transfers))
+	}
 }
 
 func TestAccountTokens(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/token-accounts", testAccount, newTestItems(3, func(i int) go3s.TokenAccount {
+			return go3s.TokenAccount{TokenAccount: strconv.Itoa(i), TokenAddress: testToken}
+		}))
+	})
 	tokens, err := client.AccountTokenAccounts(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(tokens))
+	if len(tokens) != 3 || tokens[0].TokenAddress != testToken {
+		t.Fatalf("tokens = %+v, want 3", tokens)
+	}
 }
 
 func TestAccountTokenAccountsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
-	transfers, err := client.AccountTokenAccountsPagingQuery(
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/token-accounts", testAccount, newTestItems(25, func(i int) go3s.TokenAccount {
+			return go3s.TokenAccount{TokenAccount: strconv.Itoa(i)}
+		}))
+	})
+	accounts, err := client.AccountTokenAccountsPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transfers))
+	if len(accounts) != 25 {
+		t.Fatalf("accounts = %d, want 25", len(accounts))
+	}
 }
 
 func TestAccountDefiActivities(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/defi/activities", testAccount, newTestItems(3, func(i int) go3s.DefiActivity {
+			return go3s.DefiActivity{TransID: strconv.Itoa(i), BlockTime: int64(3 - i)}
+		}))
+	})
 	activities, err := client.AccountDefiActivities(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 3 || activities[0].TransID != "0" {
+		t.Fatalf("activities = %+v, want 3", activities)
+	}
 }
 
 func TestAccountDefiActivitiesPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/defi/activities", testAccount, newTestItems(25, func(i int) go3s.DefiActivity {
+			return go3s.DefiActivity{TransID: strconv.Itoa(i), BlockTime: int64(25 - i)}
+		}))
+	})
 	activities, err := client.AccountDefiActivitiesPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 25 {
+		t.Fatalf("activities = %d, want 25", len(activities))
+	}
 }
 
 func TestAccountBalanceChanges(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/balance_change", testAccount, newTestItems(3, func(i int) go3s.AccountChangeActivity {
+			return go3s.AccountChangeActivity{TransID: strconv.Itoa(i), BlockTime: int64(3 - i)}
+		}))
+	})
 	activities, err := client.AccountBalanceChanges(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 3 || activities[0].TransID != "0" {
+		t.Fatalf("activities = %+v, want 3", activities)
+	}
 }
 
 func TestAccountBalanceChangesPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/balance_change", testAccount, newTestItems(25, func(i int) go3s.AccountChangeActivity {
+			return go3s.AccountChangeActivity{TransID: strconv.Itoa(i), BlockTime: int64(25 - i)}
+		}))
+	})
 	activities, err := client.AccountBalanceChangesPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 25 {
+		t.Fatalf("activities = %d, want 25", len(activities))
+	}
 }
 
 func TestAccountTransactions(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddAccountTransactions(testAccount, newTestTransactions(3)...)
+	})
 	transactions, err := client.AccountTransactions(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transactions))
+	if len(transactions) != 3 || transactions[0].TxHash != "0" {
+		t.Fatalf("transactions = %+v, want 3", transactions)
+	}
 }
 
 func TestAccountTransactionsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddAccountTransactions(testAccount, newTestTransactions(120)...)
+	})
 	transactions, err := client.AccountTransactionsPagingQuery(
 		context.Background(),
 		1000,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transactions))
+	if len(transactions) != 120 || transactions[0].TxHash != "0" {
+		t.Fatalf("transactions = %d, want 120", len(transactions))
+	}
 }
 
 func TestAccountStakes(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/stake", testAccount, newTestItems(3, func(i int) go3s.AccountStake {
+			return go3s.AccountStake{StakeAccount: strconv.Itoa(i)}
+		}))
+	})
 	stakes, err := client.AccountStakes(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(stakes))
+	if len(stakes) != 3 {
+		t.Fatalf("stakes = %d, want 3", len(stakes))
+	}
 }
 
 func TestAccountStakesPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/account/stake", testAccount, newTestItems(25, func(i int) go3s.AccountStake {
+			return go3s.AccountStake{StakeAccount: strconv.Itoa(i)}
+		}))
+	})
 	stakes, err := client.AccountStakesPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(stakes))
+	if len(stakes) != 25 {
+		t.Fatalf("stakes = %d, want 25", len(stakes))
+	}
 }
 
 func TestAccountDetail(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.SetAccountDetail(testAccount, go3s.AccountDetail{Account: testAccount})
+	})
 	detail, err := client.AccountDetail(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", detail)
+	if detail.Account != testAccount {
+		t.Fatalf("account = %s", detail.Account)
+	}
 }
 
 func TestAccountRewardsExport(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/account/reward/export", testAccount, []byte("block_time\n"))
+	})
 	rewards, err := client.AccountRewardsExport(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		1716672000,
 		1716758400,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(string(rewards))
+	if string(rewards) != "block_time\n" {
+		t.Fatalf("rewards = %q", rewards)
+	}
 }
 
 func TestAccountTransfersExport(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/account/transfer/export", testAccount, []byte("block_time\n"))
+	})
 	transfers, err := client.AccountTransfersExport(
 		context.Background(),
-		"3zAQJcPLbfi2mwnPraQpfuNFh5h5PN7XLkNDJSZ5i7E5",
+		testAccount,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(string(transfers))
+	if string(transfers) != "block_time\n" {
+		t.Fatalf("transfers = %q", transfers)
+	}
 }
 
 func TestTokenTransfers(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddTokenTransfers(testToken, newTestTransfers(3)...)
+	})
 	transfers, err := client.TokenTransfers(
 		context.Background(),
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transfers))
+	if len(transfers) != 3 || transfers[0].TransID != "0" {
+		t.Fatalf("transfers = %+v, want 3 newest first", transfers)
+	}
 }
 
 func TestTokenTransfersPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddTokenTransfers(testToken, newTestTransfers(25)...)
+	})
 	transfers, err := client.TokenTransfersPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transfers))
+	if len(transfers) != 25 || transfers[0].TransID != "0" {
+		t.Fatalf("transfers = %+v, want 25", transfers)
+	}
 }
 
 func TestTokenDefiActivities(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/defi/activities", testToken, newTestItems(3, func(i int) go3s.DefiActivity {
+			return go3s.DefiActivity{TransID: strconv.Itoa(i), BlockTime: int64(3 - i)}
+		}))
+	})
 	activities, err := client.TokenDefiActivities(
 		context.Background(),
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 3 || activities[0].TransID != "0" {
+		t.Fatalf("activities = %+v, want 3", activities)
+	}
 }
 
 func TestTokenDefiActivitiesPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/defi/activities", testToken, newTestItems(25, func(i int) go3s.DefiActivity {
+			return go3s.DefiActivity{TransID: strconv.Itoa(i), BlockTime: int64(25 - i)}
+		}))
+	})
 	activities, err := client.TokenDefiActivitiesPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 25 {
+		t.Fatalf("activities = %d, want 25", len(activities))
+	}
 }
 
 func TestTokenMarkets(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/markets", testToken+","+"So11111111111111111111111111111111111111112", newTestItems(3, func(i int) go3s.Market {
+			return go3s.Market{PoolID: strconv.Itoa(i), Token1: testToken}
+		}))
+	})
 	markets, err := client.TokenMarkets(
 		context.Background(),
-		[]string{"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC", "So11111111111111111111111111111111111111112"},
+		[]string{testToken, "So11111111111111111111111111111111111111112"},
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(markets))
+	if len(markets) != 3 || markets[0].Token1 != testToken {
+		t.Fatalf("markets = %+v, want 3", markets)
+	}
 }
 
 func TestTokenMarketsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/markets", testToken+","+"So11111111111111111111111111111111111111112", newTestItems(25, func(i int) go3s.Market {
+			return go3s.Market{PoolID: strconv.Itoa(i)}
+		}))
+	})
 	markets, err := client.TokenMarketsPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		[]string{"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC", "So11111111111111111111111111111111111111112"},
+		[]string{testToken, "So11111111111111111111111111111111111111112"},
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(markets))
+	if len(markets) != 25 {
+		t.Fatalf("markets = %d, want 25", len(markets))
+	}
 }
 
 func TestTokenList(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/list", "", newTestItems(3, func(i int) go3s.Token {
+			return go3s.Token{Address: strconv.Itoa(i)}
+		}))
+	})
 	tokens, err := client.TokenList(
 		context.Background(),
 		nil,
@@ -323,11 +519,17 @@ func TestTokenList(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(tokens))
+	if len(tokens) != 3 {
+		t.Fatalf("tokens = %d, want 3", len(tokens))
+	}
 }
 
 func TestTokenListPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/list", "", newTestItems(25, func(i int) go3s.Token {
+			return go3s.Token{Address: strconv.Itoa(i)}
+		}))
+	})
 	tokens, err := client.TokenListPagingQuery(
 		context.Background(),
 		1,
@@ -338,11 +540,17 @@ func TestTokenListPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(tokens))
+	if len(tokens) != 25 {
+		t.Fatalf("tokens = %d, want 25", len(tokens))
+	}
 }
 
 func TestTokenTrending(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/token/trending", "", newTestItems(3, func(i int) go3s.Token {
+			return go3s.Token{Address: strconv.Itoa(i)}
+		}))
+	})
 	tokens, err := client.TokenTrending(
 		context.Background(),
 		100,
@@ -350,172 +558,234 @@ func TestTokenTrending(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(tokens))
+	if len(tokens) != 3 {
+		t.Fatalf("tokens = %d, want 3", len(tokens))
+	}
 }
 
 func TestTokenPrice(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/token/price", testToken, []go3s.TokenPrice{{Date: 20250101, Price: 1.5}, {Date: 20250102, Price: 1.6}})
+	})
 	price, err := client.TokenPrice(
 		context.Background(),
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		"20250101",
 		"20250120",
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(price)
+	if len(price) != 2 || price[0].Date != 20250101 {
+		t.Fatalf("price = %+v, want 2", price)
+	}
 }
 
 func TestTokenHolders(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddTokenHolders(testToken, go3s.TokenHolder{Address: "a", Rank: 1}, go3s.TokenHolder{Address: "b", Rank: 2})
+	})
 	holders, err := client.TokenHolders(
 		context.Background(),
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(holders.Items), holders.Total)
+	if len(holders.Items) != 2 || holders.Total != 2 || holders.Items[0].Address != "a" {
+		t.Fatalf("holders = %+v, want a and b of 2", holders)
+	}
 }
 
 func TestTokenHoldersPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddTokenHolders(testToken, go3s.TokenHolder{Address: "a", Rank: 1}, go3s.TokenHolder{Address: "b", Rank: 2})
+	})
 	holders, err := client.TokenHoldersPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(holders.Items), holders.Total)
+	if len(holders.Items) != 2 || holders.Total != 2 {
+		t.Fatalf("holders = %+v, want 2", holders)
+	}
 }
 
 func TestTokenMeta(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.SetTokenMeta(testToken, go3s.TokenMeta{Address: testToken, Symbol: "ai16z", Decimals: 9})
+	})
 	meta, err := client.TokenMeta(
 		context.Background(),
-		"HeLp6NuQkmYB4pYWo2zYs22mESHXPQYzXbB8n4V98jwC",
+		testToken,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(meta)
+	if meta.Address != testToken || meta.Symbol != "ai16z" || meta.Decimals != 9 {
+		t.Fatalf("meta = %+v", meta)
+	}
 }
 
 func TestTokenTop(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/token/top", "", []go3s.TokenTop{{Address: testToken}})
+	})
 	tokens, err := client.TokenTop(
 		context.Background(),
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(tokens))
+	if len(tokens) != 1 || tokens[0].Address != testToken {
+		t.Fatalf("tokens = %+v, want 1", tokens)
+	}
 }
 
 func TestTxLast(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/transaction/last", "", newTestTransactions(120))
+	})
 	txs, err := client.TxLast(
 		context.Background(),
-		&TxLastParams{Limit: LargePageSize100},
+		&go3s.TxLastParams{Limit: go3s.LargePageSize100},
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(txs))
+	if len(txs) != 100 {
+		t.Fatalf("txs = %d, want 100", len(txs))
+	}
 }
 
 func TestTxDetail(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.SetTxDetail(testTx, go3s.TransactionDetail{TxHash: testTx})
+	})
 	tx, err := client.TxDetail(
 		context.Background(),
-		"3uf5w7XnMBd4xZTRTqSzi1L9S1QBFCMxAutTd4vCAdPWnjf5b815Ng2GfQwVRf4qHxHDDFWHT6tndHSD88HQkbSk",
+		testTx,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", tx)
+	if tx.TxHash != testTx {
+		t.Fatalf("tx hash = %s", tx.TxHash)
+	}
 }
 
 func TestTxActions(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.SetTxActions(testTx, go3s.TransactionAction{TxHash: testTx})
+	})
 	actions, err := client.TxActions(
 		context.Background(),
-		"3uf5w7XnMBd4xZTRTqSzi1L9S1QBFCMxAutTd4vCAdPWnjf5b815Ng2GfQwVRf4qHxHDDFWHT6tndHSD88HQkbSk",
+		testTx,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", actions)
+	if actions.TxHash != testTx {
+		t.Fatalf("tx hash = %s", actions.TxHash)
+	}
 }
 
 func TestBlockLast(t *testing.T) {
-	client := NewV2Client("")
-	blocks, err := client.BlocksLast(context.Background(), LargePageSize100)
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/block/last", "", newTestItems(3, func(i int) go3s.BlockDetail {
+			return go3s.BlockDetail{CurrentSlot: testBlock - int64(i)}
+		}))
+	})
+	blocks, err := client.BlocksLast(context.Background(), go3s.LargePageSize100)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", blocks)
+	if len(blocks) != 3 || blocks[0].CurrentSlot != testBlock {
+		t.Fatalf("blocks = %+v, want 3", blocks)
+	}
 }
 
 func TestBlockTransactions(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddBlockTransactions(testBlock, newTestTransactions(3)...)
+	})
 	transactions, err := client.BlockTransactions(
 		context.Background(),
-		315989642,
+		testBlock,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transactions.Transactions), transactions.Total)
+	if len(transactions.Transactions) != 3 || transactions.Total != 3 {
+		t.Fatalf("transactions = %d of %d, want 3", len(transactions.Transactions), transactions.Total)
+	}
 }
 
 func TestBlockTransactionsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.AddBlockTransactions(testBlock, newTestTransactions(30)...)
+	})
 	transactions, err := client.BlockTransactionsPagingQuery(
 		context.Background(),
 		1,
 		388,
 		10,
-		315989642,
+		testBlock,
 		nil,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(transactions.Transactions), transactions.Total)
+	if len(transactions.Transactions) != 30 || transactions.Total != 30 {
+		t.Fatalf("transactions = %d of %d, want 30", len(transactions.Transactions), transactions.Total)
+	}
 }
 
 func TestBlockDetail(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.SetBlockDetail(testBlock, go3s.BlockDetail{CurrentSlot: testBlock})
+	})
 	block, err := client.BlockDetail(
 		context.Background(),
-		315989642,
+		testBlock,
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", block)
+	if block.CurrentSlot != testBlock {
+		t.Fatalf("current slot = %d, want %d", block.CurrentSlot, testBlock)
+	}
 }
 
 func TestPoolMarketList(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/market/list", "", newTestItems(3, func(i int) go3s.PoolMarket {
+			return go3s.PoolMarket{PoolAddress: strconv.Itoa(i)}
+		}))
+	})
 	markets, err := client.PoolMarketList(context.Background(), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(markets))
+	if len(markets) != 3 {
+		t.Fatalf("markets = %d, want 3", len(markets))
+	}
 }
 
 func TestPoolMarketListPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/market/list", "", newTestItems(25, func(i int) go3s.PoolMarket {
+			return go3s.PoolMarket{PoolAddress: strconv.Itoa(i)}
+		}))
+	})
 	markets, err := client.PoolMarketListPagingQuery(
 		context.Background(),
 		1,
@@ -526,11 +796,15 @@ func TestPoolMarketListPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(markets))
+	if len(markets) != 25 {
+		t.Fatalf("markets = %d, want 25", len(markets))
+	}
 }
 
 func TestPoolMarketInfo(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/market/info", "44W73kGYQgXCTNkGxUmHv8DDBPCxojBcX49uuKmbFc9U", go3s.PoolMarketInfo{PoolAddress: "44W73kGYQgXCTNkGxUmHv8DDBPCxojBcX49uuKmbFc9U"})
+	})
 	info, err := client.PoolMarketInfo(
 		context.Background(),
 		"44W73kGYQgXCTNkGxUmHv8DDBPCxojBcX49uuKmbFc9U",
@@ -538,11 +812,15 @@ func TestPoolMarketInfo(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", info)
+	if info.PoolAddress != "44W73kGYQgXCTNkGxUmHv8DDBPCxojBcX49uuKmbFc9U" {
+		t.Fatalf("pool address = %s", info.PoolAddress)
+	}
 }
 
 func TestPoolMarketVolume(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/market/volume", "FDxGM9n4UQjUunjb43be1hs8oYFAPYziX1bWWc212dVU", go3s.PoolMarketVolume{PoolAddress: "FDxGM9n4UQjUunjb43be1hs8oYFAPYziX1bWWc212dVU"})
+	})
 	volume, err := client.PoolMarketVolume(
 		context.Background(),
 		"FDxGM9n4UQjUunjb43be1hs8oYFAPYziX1bWWc212dVU",
@@ -552,29 +830,45 @@ func TestPoolMarketVolume(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", volume)
+	if volume.PoolAddress != "FDxGM9n4UQjUunjb43be1hs8oYFAPYziX1bWWc212dVU" {
+		t.Fatalf("pool address = %s", volume.PoolAddress)
+	}
 }
 
 func TestAPIUsage(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Seed("/monitor/usage", "", go3s.APIUsage{RemainingCUs: 1})
+	})
 	usage, err := client.APIUsage(context.Background())
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Printf("%+v\n", usage)
+	if usage.RemainingCUs != 1 {
+		t.Fatalf("remaining CUs = %d, want 1", usage.RemainingCUs)
+	}
 }
 
 func TestNFTNews(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/news", "", newTestItems(3, func(i int) go3s.NFTInfo {
+			return go3s.NFTInfo{Address: strconv.Itoa(i)}
+		}))
+	})
 	news, err := client.NFTNews(context.Background(), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(news.Data))
+	if len(news.Data) != 3 || news.Total != 3 {
+		t.Fatalf("news = %d of %d, want 3", len(news.Data), news.Total)
+	}
 }
 
 func TestNFTNewsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/news", "", newTestItems(25, func(i int) go3s.NFTInfo {
+			return go3s.NFTInfo{Address: strconv.Itoa(i)}
+		}))
+	})
 	news, err := client.NFTNewsPagingQuery(
 		context.Background(),
 		1,
@@ -585,25 +879,37 @@ func TestNFTNewsPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(news.Data))
+	if len(news.Data) != 25 {
+		t.Fatalf("news = %d, want 25", len(news.Data))
+	}
 }
 
 func TestNFTActivities(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/activities", "", newTestItems(3, func(i int) go3s.NFTActivity {
+			return go3s.NFTActivity{TransID: strconv.Itoa(i), BlockTime: int64(3 - i)}
+		}))
+	})
 	activities, err := client.NFTActivities(
 		context.Background(),
-		&NFTActivitiesParams{
+		&go3s.NFTActivitiesParams{
 			Source: []string{"opensea"},
 		},
 	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 3 {
+		t.Fatalf("activities = %d, want 3", len(activities))
+	}
 }
 
 func TestNFTActivitiesPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/activities", "", newTestItems(25, func(i int) go3s.NFTActivity {
+			return go3s.NFTActivity{TransID: strconv.Itoa(i), BlockTime: int64(25 - i)}
+		}))
+	})
 	activities, err := client.NFTActivitiesPagingQuery(
 		context.Background(),
 		1,
@@ -614,20 +920,32 @@ func TestNFTActivitiesPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(activities))
+	if len(activities) != 25 {
+		t.Fatalf("activities = %d, want 25", len(activities))
+	}
 }
 
 func TestNFTCollectionList(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/collection/lists", "", newTestItems(3, func(i int) go3s.NFTCollection {
+			return go3s.NFTCollection{CollectionID: strconv.Itoa(i)}
+		}))
+	})
 	collections, err := client.NFTCollectionList(context.Background(), nil)
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(collections))
+	if len(collections) != 3 {
+		t.Fatalf("collections = %d, want 3", len(collections))
+	}
 }
 
 func TestNFTCollectionListPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/collection/lists", "", newTestItems(25, func(i int) go3s.NFTCollection {
+			return go3s.NFTCollection{CollectionID: strconv.Itoa(i)}
+		}))
+	})
 	collections, err := client.NFTCollectionListPagingQuery(
 		context.Background(),
 		1,
@@ -638,11 +956,17 @@ func TestNFTCollectionListPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(collections))
+	if len(collections) != 25 {
+		t.Fatalf("collections = %d, want 25", len(collections))
+	}
 }
 
 func TestNFTCollectionItems(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/collection/items", "CY2E69dSG9vBsMoaXDvYmMDSMEP4SZtRY1rqVQ9tkNDu", newTestItems(3, func(i int) go3s.NFTCollectionItem {
+			return go3s.NFTCollectionItem{Info: go3s.NFTItemInfo{Address: strconv.Itoa(i)}}
+		}))
+	})
 	items, err := client.NFTCollectionItems(
 		context.Background(),
 		"CY2E69dSG9vBsMoaXDvYmMDSMEP4SZtRY1rqVQ9tkNDu",
@@ -651,11 +975,17 @@ func TestNFTCollectionItems(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(items))
+	if len(items) != 3 {
+		t.Fatalf("items = %d, want 3", len(items))
+	}
 }
 
 func TestNFTCollectionItemsPagingQuery(t *testing.T) {
-	client := NewV2Client("")
+	client := newTestClient(t, func(s *go3stest.Server) {
+		s.Add("/nft/collection/items", "CY2E69dSG9vBsMoaXDvYmMDSMEP4SZtRY1rqVQ9tkNDu", newTestItems(25, func(i int) go3s.NFTCollectionItem {
+			return go3s.NFTCollectionItem{Info: go3s.NFTItemInfo{Address: strconv.Itoa(i)}}
+		}))
+	})
 	items, err := client.NFTCollectionItemsPagingQuery(
 		context.Background(),
 		1,
@@ -667,5 +997,65 @@ func TestNFTCollectionItemsPagingQuery(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	fmt.Println(len(items))
+	if len(items) != 25 {
+		t.Fatalf("items = %d, want 25", len(items))
+	}
+}
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestWithTransport(t *testing.T) {
+	var gotURL string
+	rt := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		gotURL = req.URL.String()
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Header:     http.Header{"Content-Type": {"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(`{"success":true,"data":{"blockHeight":42}}`)),
+			Request:    req,
+		}, nil
+	})
+	client := go3s.NewV2Client("test", go3s.WithTransport(rt))
+	chainInfo, err := client.ChainInfo(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if chainInfo.BlockHeight != 42 {
+		t.Fatalf("block height = %d, want 42", chainInfo.BlockHeight)
+	}
+	if gotURL != go3s.PUBLIC_BASE_URL+"/chaininfo" {
+		t.Fatalf("url = %s", gotURL)
+	}
+}
+
+func TestNewClientOptions(t *testing.T) {
+	var gotPath, gotToken, gotUA, gotExtra string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		gotPath = r.URL.Path
+		gotToken = r.Header.Get("token")
+		gotUA = r.Header.Get("User-Agent")
+		gotExtra = r.Header.Get("X-Extra")
+		w.Write([]byte(`{"success":true,"data":{"address":"abc","decimals":6}}`))
+	}))
+	defer srv.Close()
+	client := go3s.NewClient(
+		go3s.WithToken("secret"),
+		go3s.WithProBaseURL(srv.URL+"/v2.0/"),
+		go3s.WithUserAgent("go3s-test"),
+		go3s.WithHeader("X-Extra", "1"),
+	)
+	meta, err := client.TokenMeta(context.Background(), "abc")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Decimals != 6 {
+		t.Fatalf("decimals = %d, want 6", meta.Decimals)
+	}
+	if gotPath != "/v2.0/token/meta" || gotToken != "secret" || gotUA != "go3s-test" || gotExtra != "1" {
+		t.Fatalf("unexpected request: path=%s token=%s ua=%s extra=%s", gotPath, gotToken, gotUA, gotExtra)
+	}
 }
@@ -0,0 +1,156 @@
+package go3s
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+)
+
+// CursorGetter pages through an endpoint paginated by cursor instead of page number,
+// e.g. /account/transactions and its before param. Every request passes the cursor
+// of the last item of the previous page, until a page is shorter than the limit.
+// Do and Iter leave the getter unchanged, so it can be reused.
+type CursorGetter[T any] struct {
+	BaseURL      string
+	Path         string
+	Params       url.Values
+	Headers      map[string][]string
+	Limiter      Limiter
+	HTTPClient   *http.Client
+	CU           int64
+	GetterOption *GetterOption
+	// CursorParam is the param carrying the cursor, e.g. "before".
+	// Its value in Params, if any, is the first cursor.
+	CursorParam string
+	// LimitParam is the param carrying the page size, e.g. "limit".
+	LimitParam string
+	// PageSizes lists the limits accepted by the endpoint, nil accepts any.
+	PageSizes []int64
+	// Cursor returns the cursor of an item, the next page starts after it.
+	Cursor func(t T) string
+	// TotalSize is the number of items to fetch, 0 or less fetches all.
+	TotalSize int64
+	// MaxPages caps the number of pages fetched, 0 means no cap.
+	MaxPages int64
+	// Checkpoint, if set, makes the run resumable from the last cursor, see Checkpoint.
+	Checkpoint Checkpoint
+}
+
+func (g *CursorGetter[T]) URL() string {
+	return ""
+}
+
+func (g *CursorGetter[T]) Do(ctx context.Context) ([]T, error) {
+	limit, err := g.limit()
+	if err != nil {
+		return nil, err
+	}
+	cp := g.Checkpoint
+	var state CheckpointState
+	if cp != nil {
+		if state, err = loadCheckpoint(cp, checkpointQuery(g.Path, g.Params, g.CursorParam)); err != nil {
+			return nil, err
+		}
+		if state.Done {
+			return []T{}, nil
+		}
+	}
+	totalSize := g.TotalSize
+	if totalSize > 0 {
+		if totalSize -= state.Fetched; totalSize <= 0 {
+			return []T{}, nil
+		}
+	}
+	cursor := g.Params.Get(g.CursorParam)
+	if state.Cursor != "" {
+		cursor = state.Cursor
+	}
+	items := []T{}
+	for page := int64(0); g.MaxPages <= 0 || page < g.MaxPages; page++ {
+		if totalSize > 0 && int64(len(items)) >= totalSize {
+			break
+		}
+		ts, err := g.page(ctx, cursor)
+		if err != nil {
+			if cp != nil {
+				return items, &CheckpointError{State: state, Err: err}
+			}
+			return nil, err
+		}
+		items = append(items, ts...)
+		if int64(len(ts)) < limit {
+			break
+		}
+		cursor = g.Cursor(ts[len(ts)-1])
+		if cp != nil {
+			state.Cursor = cursor
+			state.Fetched += int64(len(ts))
+			if err := cp.Save(state); err != nil {
+				return items, err
+			}
+		}
+	}
+	if cp != nil {
+		state.Done = true
+		if err := cp.Save(state); err != nil {
+			return items, err
+		}
+	}
+	return items[:truncatedLen(len(items), totalSize)], nil
+}
+
+// Iter returns an Iterator walking the pages lazily.
+// TotalSize, MaxPages and Checkpoint are not used.
+func (g *CursorGetter[T]) Iter(ctx context.Context) *Iterator[T] {
+	limit, limitErr := g.limit()
+	cursor := g.Params.Get(g.CursorParam)
+	return NewIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		if limitErr != nil {
+			return nil, false, limitErr
+		}
+		ts, err := g.page(ctx, cursor)
+		if err != nil {
+			return nil, false, err
+		}
+		if len(ts) > 0 {
+			cursor = g.Cursor(ts[len(ts)-1])
+		}
+		return ts, int64(len(ts)) >= limit, nil
+	})
+}
+
+// limit returns the LimitParam param, checked against PageSizes.
+func (g *CursorGetter[T]) limit() (int64, error) {
+	limit, err := strconv.ParseInt(g.Params.Get(g.LimitParam), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("solscan: can not get %s: %s", g.LimitParam, err.Error())
+	}
+	if limit <= 0 || len(g.PageSizes) > 0 && !slices.Contains(g.PageSizes, limit) {
+		return 0, fmt.Errorf("%w: %s %d", ErrInvalidPageSize, g.LimitParam, limit)
+	}
+	return limit, nil
+}
+
+// page fetches the page after cursor, the first page if cursor is empty.
+func (g *CursorGetter[T]) page(ctx context.Context, cursor string) ([]T, error) {
+	params := cloneParams(g.Params)
+	if cursor == "" {
+		params.Del(g.CursorParam)
+	} else {
+		params.Set(g.CursorParam, cursor)
+	}
+	sg := SimpleGetter[[]T]{
+		BaseURL:    g.BaseURL,
+		Path:       g.Path,
+		Params:     params,
+		Headers:    g.Headers,
+		Limiter:    g.Limiter,
+		HTTPClient: g.HTTPClient,
+		CU:         g.CU,
+		Option:     g.GetterOption,
+	}
+	return sg.Do(ctx)
+}
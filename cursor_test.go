@@ -0,0 +1,112 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+func newTransactionsServer(t *testing.T, total int) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := 0
+		if before := r.URL.Query().Get("before"); before != "" {
+			start, _ = strconv.Atoi(before[len("tx"):])
+			start++
+		}
+		limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+		data := []Transaction{}
+		for i := start; i < start+limit && i < total; i++ {
+			data = append(data, Transaction{TxHash: "tx" + strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transaction]{Success: true, Data: data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCursorGetter(t *testing.T) {
+	srv := newTransactionsServer(t, 35)
+	client := NewClient(WithProBaseURL(srv.URL))
+	params := &AccountTransactionsParams{Limit: SmallPageSize10}
+
+	txs, err := client.AccountTransactionsPagingQuery(context.Background(), 25, "addr", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 25 || txs[24].TxHash != "tx24" {
+		t.Fatalf("transactions = %d, want tx0 to tx24", len(txs))
+	}
+	if *params != (AccountTransactionsParams{Limit: SmallPageSize10}) {
+		t.Fatalf("params were changed to %+v", *params)
+	}
+
+	g := client.accountTransactionsCursorGetter("addr", params)
+	for range 2 {
+		txs, err = g.Do(context.Background())
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(txs) != 35 {
+			t.Fatalf("transactions = %d, want 35", len(txs))
+		}
+	}
+
+	it := client.AccountTransactionsIter(context.Background(), "addr", &AccountTransactionsParams{Before: "tx29", Limit: SmallPageSize10})
+	n := 0
+	for it.Next() {
+		n++
+	}
+	if it.Err() != nil || n != 5 {
+		t.Fatalf("iterated %d, err = %v, want 5", n, it.Err())
+	}
+
+	_, err = client.AccountTransactionsPagingQuery(context.Background(), 0, "addr", &AccountTransactionsParams{Limit: 15})
+	if !errors.Is(err, ErrInvalidPageSize) {
+		t.Fatalf("err = %v, want ErrInvalidPageSize", err)
+	}
+}
+
+func TestCursorGetterCheckpointTotalSize(t *testing.T) {
+	upstream := newTransactionsServer(t, 50)
+	var failing atomic.Bool
+	failing.Store(true)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if failing.Load() && r.URL.Query().Get("before") == "tx9" {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		resp, err := http.Get(upstream.URL + r.URL.RequestURI())
+		if err != nil {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		defer resp.Body.Close()
+		io.Copy(w, resp.Body)
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL)).WithCheckpoint(NewFileCheckpoint(filepath.Join(t.TempDir(), "job.json")))
+	params := &AccountTransactionsParams{Limit: SmallPageSize10}
+
+	txs, err := client.AccountTransactionsPagingQuery(context.Background(), 30, "addr", params)
+	var cpErr *CheckpointError
+	if !errors.As(err, &cpErr) || cpErr.State.Fetched != 10 || len(txs) != 10 {
+		t.Fatalf("transactions = %d, err = %v, want 10 fetched before the failure", len(txs), err)
+	}
+
+	failing.Store(false)
+	txs, err = client.AccountTransactionsPagingQuery(context.Background(), 30, "addr", params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(txs) != 20 || txs[0].TxHash != "tx10" || txs[19].TxHash != "tx29" {
+		t.Fatalf("transactions = %d, want tx10 to tx29, 30 in total", len(txs))
+	}
+}
@@ -0,0 +1,182 @@
+package go3s
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// maxErrorBodyLen is how many bytes of a failed response body APIError keeps.
+const maxErrorBodyLen = 1024
+
+var requestIDHeaders = []string{"X-Request-Id", "X-Amzn-Requestid", "Cf-Ray"}
+
+var redactedParams = []string{"token", "api_key", "apikey", "key"}
+
+// APIError describes a failed Solscan response.
+// It unwraps to the matching sentinel error, e.g. errors.Is(err, Err429),
+// and to *Errors when the body carries a Solscan error code or message.
+type APIError struct {
+	StatusCode int
+	Method     string
+	URL        string // credentials in the query are redacted
+	Code       int64  // Solscan error code, if any
+	Message    string // Solscan error message, if any
+	RequestID  string
+	Body       string // at most maxErrorBodyLen bytes
+	Err        error  // sentinel such as Err429, may be nil
+
+	nonJSON bool
+}
+
+// NewAPIError creates an APIError from resp and its already read body.
+// Err is left for the caller to set.
+func NewAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{}
+	e.setRequest(resp)
+	e.nonJSON = len(bytes.TrimSpace(body)) > 0 && !json.Valid(body)
+	if len(body) > maxErrorBodyLen {
+		e.Body = string(body[:maxErrorBodyLen])
+	} else {
+		e.Body = string(body)
+	}
+	var respError RespError
+	if json.Unmarshal(body, &respError) == nil {
+		e.Code = respError.Errors.Code
+		e.Message = respError.Errors.Message
+	}
+	return e
+}
+
+// setRequest fills the status, request and request ID fields from resp.
+func (e *APIError) setRequest(resp *http.Response) {
+	e.StatusCode = resp.StatusCode
+	if resp.Request != nil {
+		e.Method = resp.Request.Method
+		if resp.Request.URL != nil {
+			e.URL = redactURL(resp.Request.URL)
+		}
+	}
+	for _, h := range requestIDHeaders {
+		if v := resp.Header.Get(h); v != "" {
+			e.RequestID = v
+			break
+		}
+	}
+}
+
+func (e *APIError) Error() string {
+	var b strings.Builder
+	if e.Err != nil {
+		b.WriteString(e.Err.Error())
+	} else {
+		fmt.Fprintf(&b, "solscan: %d %s", e.StatusCode, strings.ToLower(http.StatusText(e.StatusCode)))
+	}
+	if e.URL != "" {
+		fmt.Fprintf(&b, ", %s %s", e.Method, e.URL)
+	}
+	if e.Code != 0 || e.Message != "" {
+		fmt.Fprintf(&b, ", code: %d, message: %s", e.Code, e.Message)
+	}
+	if e.RequestID != "" {
+		fmt.Fprintf(&b, ", request id: %s", e.RequestID)
+	}
+	return b.String()
+}
+
+// Temporary reports whether the request may succeed if retried:
+// 429, 5xx and non-JSON bodies, e.g. HTML pages, served with 200
+// in place of the API response.
+func (e *APIError) Temporary() bool {
+	if e.StatusCode == http.StatusTooManyRequests || e.StatusCode >= http.StatusInternalServerError {
+		return true
+	}
+	return e.Err == ErrNonJSONBody
+}
+
+func (e *APIError) Unwrap() []error {
+	var errs []error
+	if e.Err != nil {
+		errs = append(errs, e.Err)
+	}
+	if e.nonJSON && e.Err != ErrNonJSONBody {
+		errs = append(errs, ErrNonJSONBody)
+	}
+	if e.Code != 0 || e.Message != "" {
+		errs = append(errs, &Errors{Code: e.Code, Message: e.Message})
+	}
+	return errs
+}
+
+// PageError is the error of a single failed page.
+type PageError struct {
+	Page int64 // page number, or getter index for CcrtGetter
+	Err  error
+}
+
+// PageErrors is returned along with the fetched results when some pages
+// of a run with PartialResults failed.
+type PageErrors []PageError
+
+func (e PageErrors) Error() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "solscan: %d pages failed", len(e))
+	for _, pe := range e {
+		fmt.Fprintf(&b, "; page %d: %s", pe.Page, pe.Err.Error())
+	}
+	return b.String()
+}
+
+func (e PageErrors) Unwrap() []error {
+	errs := make([]error, len(e))
+	for i, pe := range e {
+		errs[i] = pe.Err
+	}
+	return errs
+}
+
+// Pages returns the failed pages, e.g. to retry only them.
+func (e PageErrors) Pages() []int64 {
+	pages := make([]int64, len(e))
+	for i, pe := range e {
+		pages[i] = pe.Page
+	}
+	return pages
+}
+
+// looksJSON reports whether body starts like a JSON object or array.
+func looksJSON(body []byte) bool {
+	trimmed := bytes.TrimSpace(body)
+	return len(trimmed) > 0 && (trimmed[0] == '{' || trimmed[0] == '[')
+}
+
+// isHTML reports whether a response looks like an HTML page.
+func isHTML(contentType string, body []byte) bool {
+	if strings.HasPrefix(strings.ToLower(contentType), "text/html") {
+		return true
+	}
+	trimmed := bytes.ToLower(bytes.TrimSpace(body))
+	return bytes.HasPrefix(trimmed, []byte("<!doctype html")) || bytes.HasPrefix(trimmed, []byte("<html"))
+}
+
+func redactURL(u *url.URL) string {
+	ru := *u
+	ru.User = nil
+	q := ru.Query()
+	changed := false
+	for k := range q {
+		for _, p := range redactedParams {
+			if strings.EqualFold(k, p) {
+				q.Set(k, "REDACTED")
+				changed = true
+			}
+		}
+	}
+	if changed {
+		ru.RawQuery = q.Encode()
+	}
+	return ru.String()
+}
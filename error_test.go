@@ -0,0 +1,107 @@
+package go3s
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestDefaultRespStatusHandlerAPIError(t *testing.T) {
+	u, _ := url.Parse("https://pro-api.solscan.io/v2.0/token/meta?address=abc&token=secret")
+	resp := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Header:     http.Header{"X-Request-Id": {"req-1"}},
+		Body:       io.NopCloser(strings.NewReader(`{"success":false,"errors":{"code":1100,"message":"Validation Error"}}`)),
+		Request:    &http.Request{Method: http.MethodGet, URL: u},
+	}
+	err := DefaultRespStatusHandler(resp)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) {
+		t.Fatalf("err = %T, want *APIError", err)
+	}
+	if apiErr.StatusCode != 400 || apiErr.Method != "GET" || apiErr.RequestID != "req-1" || apiErr.Code != 1100 {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+	if strings.Contains(apiErr.URL, "secret") || strings.Contains(err.Error(), "secret") {
+		t.Fatalf("token not redacted: %s", apiErr.URL)
+	}
+	if !errors.Is(err, Err400) {
+		t.Fatal("errors.Is(err, Err400) = false")
+	}
+	var solscanErr *Errors
+	if !errors.As(err, &solscanErr) || solscanErr.Message != "Validation Error" {
+		t.Fatalf("errors.As(err, *Errors) = %v", solscanErr)
+	}
+}
+
+func TestDefaultRespStatusHandlerSentinels(t *testing.T) {
+	for status, sentinel := range map[int]error{
+		http.StatusUnauthorized:        Err401,
+		http.StatusForbidden:           Err403,
+		http.StatusNotFound:            Err404,
+		http.StatusTooManyRequests:     Err429,
+		http.StatusInternalServerError: Err500,
+	} {
+		resp := &http.Response{StatusCode: status, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(""))}
+		if err := DefaultRespStatusHandler(resp); !errors.Is(err, sentinel) {
+			t.Fatalf("status %d: err = %v, want %v", status, err, sentinel)
+		}
+	}
+}
+
+func TestDefaultRespStatusHandlerNonJSON(t *testing.T) {
+	page := "<!DOCTYPE html><html><body>502 Bad Gateway</body></html>"
+	cases := []struct {
+		status    int
+		header    http.Header
+		body      string
+		sentinels []error
+		temporary bool
+	}{
+		{http.StatusBadGateway, http.Header{"Content-Type": {"text/html"}}, page, []error{Err502, ErrNonJSONBody}, true},
+		{http.StatusServiceUnavailable, http.Header{}, "", []error{Err503}, true},
+		{http.StatusGatewayTimeout, http.Header{}, "", []error{Err504}, true},
+		{http.StatusBadRequest, http.Header{}, "bad address", []error{Err400, ErrNonJSONBody}, false},
+		{http.StatusOK, http.Header{"Content-Type": {"text/html; charset=utf-8"}}, page, []error{ErrNonJSONBody}, true},
+		{http.StatusOK, http.Header{"Content-Type": {"text/plain"}}, "upstream connect error", []error{ErrNonJSONBody}, true},
+		{http.StatusOK, http.Header{}, "", []error{ErrNonJSONBody}, true},
+	}
+	for _, c := range cases {
+		resp := &http.Response{StatusCode: c.status, Header: c.header, Body: io.NopCloser(strings.NewReader(c.body))}
+		err := DefaultRespStatusHandler(resp)
+		for _, s := range c.sentinels {
+			if !errors.Is(err, s) {
+				t.Fatalf("status %d: err = %v, want %v", c.status, err, s)
+			}
+		}
+		var apiErr *APIError
+		if !errors.As(err, &apiErr) || apiErr.Temporary() != c.temporary {
+			t.Fatalf("status %d: temporary = %v, want %v", c.status, apiErr.Temporary(), c.temporary)
+		}
+		if DefaultShouldRetry(err, resp) != c.temporary {
+			t.Fatalf("status %d: DefaultShouldRetry disagrees with Temporary", c.status)
+		}
+	}
+
+	body := `{"success":true,"data":{"blockHeight":1}}`
+	resp := &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(body))}
+	if err := DefaultRespStatusHandler(resp); err != nil {
+		t.Fatal(err)
+	}
+	if b, _ := io.ReadAll(resp.Body); string(b) != body {
+		t.Fatalf("body not restored: %s", b)
+	}
+
+	csv := "block_time,amount\n1,2\n"
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{"Content-Type": {"text/csv"}}, Body: io.NopCloser(strings.NewReader(csv))}
+	if err := ExportRespStatusHandler(resp); err != nil {
+		t.Fatal(err)
+	}
+	resp = &http.Response{StatusCode: http.StatusOK, Header: http.Header{}, Body: io.NopCloser(strings.NewReader(page))}
+	if err := ExportRespStatusHandler(resp); !errors.Is(err, ErrNonJSONBody) {
+		t.Fatalf("err = %v, want ErrNonJSONBody", err)
+	}
+}
@@ -1,27 +1,45 @@
 package go3s
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
 	"math"
+	"math/rand"
+	"net"
 	"net/http"
 	"net/url"
+	"slices"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
-	"golang.org/x/sync/errgroup"
-	"golang.org/x/time/rate"
+	"golang.org/x/sync/singleflight"
 )
 
 var (
+	Err400 = fmt.Errorf("solscan: 400 bad request")
 	Err401 = fmt.Errorf("solscan: 401 unauthorized")
 	Err403 = fmt.Errorf("solscan: 403 forbidden")
 	Err404 = fmt.Errorf("solscan: 404 not found")
 	Err429 = fmt.Errorf("solscan: 429 too many requests")
 	Err500 = fmt.Errorf("solscan: 500 internal server error")
+	Err502 = fmt.Errorf("solscan: 502 bad gateway")
+	Err503 = fmt.Errorf("solscan: 503 service unavailable")
+	Err504 = fmt.Errorf("solscan: 504 gateway timeout")
+
+	// ErrNonJSONBody is matched when the response body is not JSON,
+	// e.g. an HTML error page served by a proxy or CDN.
+	ErrNonJSONBody = fmt.Errorf("solscan: response body is not json")
+	// ErrUnsuccessful is matched when a 200 response carries success:false.
+	ErrUnsuccessful = fmt.Errorf("solscan: response is not successful")
+	// ErrInvalidPageSize is returned by PagingGetter when page_size is not accepted by the endpoint.
+	ErrInvalidPageSize = fmt.Errorf("solscan: invalid page_size")
 )
 
 func createParams[Opt any](optParams *Opt, requiredParams ...string) url.Values {
@@ -45,53 +63,177 @@ type Getter[D any] interface {
 	URL() string
 }
 
+// ExportBodyUnmarshal returns the raw body of an export endpoint,
+// unless the body is an unsuccessful JSON envelope.
 func ExportBodyUnmarshal(body []byte) ([]byte, error) {
+	trimmed := bytes.TrimSpace(body)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return body, nil
+	}
+	var envelope struct {
+		Success *bool   `json:"success"`
+		Errors  *Errors `json:"errors"`
+	}
+	if json.Unmarshal(trimmed, &envelope) != nil {
+		return body, nil
+	}
+	if err := unsuccessfulError(envelope.Success, envelope.Errors, body); err != nil {
+		return nil, err
+	}
 	return body, nil
 }
 
 func DefaultRespBodyUnmarshal[D any](body []byte) (D, error) {
-	var respData RespData[D]
+	var respData struct {
+		Success *bool   `json:"success"`
+		Data    D       `json:"data"`
+		Errors  *Errors `json:"errors"`
+	}
 	err := json.Unmarshal(body, &respData)
 	if err != nil {
 		return *new(D), err
 	}
+	if err := unsuccessfulError(respData.Success, respData.Errors, body); err != nil {
+		return *new(D), err
+	}
 	return respData.Data, nil
 }
 
+// unsuccessfulError returns an *APIError matching ErrUnsuccessful
+// if a decoded envelope has success:false or carries errors.
+// A missing success field is not treated as a failure.
+func unsuccessfulError(success *bool, errs *Errors, body []byte) error {
+	if (success == nil || *success) && errs == nil {
+		return nil
+	}
+	apiErr := NewAPIError(&http.Response{StatusCode: http.StatusOK, Header: http.Header{}}, body)
+	apiErr.Err = ErrUnsuccessful
+	return apiErr
+}
+
+// DefaultRespStatusHandler returns an *APIError for any non 200 response
+// and for a 200 response whose body is not JSON.
 func DefaultRespStatusHandler(resp *http.Response) error {
 	statusCode := resp.StatusCode
+	if statusCode == http.StatusOK {
+		return checkOKResp(resp, true)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLen*64))
+	apiErr := NewAPIError(resp, body)
+	if err != nil && apiErr.Message == "" {
+		apiErr.Message = "can not read body: " + err.Error()
+	}
 	switch statusCode {
-	case http.StatusOK:
-		return nil
 	case http.StatusBadRequest:
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return fmt.Errorf("solscan: 400 bad request, but can not read body: %s", err.Error())
-		}
-		var respError RespError
-		err = json.Unmarshal(body, &respError)
-		if err != nil {
-			return fmt.Errorf("solscan: 400 bad request, but can not unmarshal body: %s", err.Error())
-		}
-		return &respError.Errors
+		apiErr.Err = Err400
 	case http.StatusUnauthorized:
-		return Err401
+		apiErr.Err = Err401
 	case http.StatusForbidden:
-		return Err403
+		apiErr.Err = Err403
 	case http.StatusNotFound:
-		return Err404
+		apiErr.Err = Err404
 	case http.StatusTooManyRequests:
-		return Err429
+		apiErr.Err = Err429
 	case http.StatusInternalServerError:
-		return Err500
-	default:
-		return fmt.Errorf("solscan: %d unknown error", statusCode)
+		apiErr.Err = Err500
+	case http.StatusBadGateway:
+		apiErr.Err = Err502
+	case http.StatusServiceUnavailable:
+		apiErr.Err = Err503
+	case http.StatusGatewayTimeout:
+		apiErr.Err = Err504
+	}
+	return apiErr
+}
+
+// ExportRespStatusHandler is the RespStatusHandler of export endpoints,
+// which serve CSV with 200, so only HTML pages are rejected then.
+func ExportRespStatusHandler(resp *http.Response) error {
+	if resp.StatusCode == http.StatusOK {
+		return checkOKResp(resp, false)
+	}
+	return DefaultRespStatusHandler(resp)
+}
+
+// checkOKResp buffers the body of a 200 response so it can be inspected,
+// then restores it for the body unmarshaler.
+func checkOKResp(resp *http.Response, wantJSON bool) error {
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("solscan: can not read body: %s", err.Error())
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if isHTML(resp.Header.Get("Content-Type"), body) || wantJSON && !looksJSON(body) {
+		apiErr := NewAPIError(resp, body)
+		apiErr.Err = ErrNonJSONBody
+		return apiErr
 	}
+	return nil
 }
 
 type GetterOption struct {
+	// RetryInterval is the delay before the first retry.
 	RetryInterval time.Duration
-	MaxRetries    int
+	// MaxRetryInterval caps the backoff delay, 0 means no cap.
+	MaxRetryInterval time.Duration
+	// Multiplier grows the delay after every retry,
+	// 0 or 1 keeps RetryInterval constant.
+	Multiplier float64
+	// Jitter enables full jitter, sleeping a random duration in [0, delay].
+	Jitter     bool
+	MaxRetries int
+	// ShouldRetry reports whether a failed attempt is worth retrying.
+	// resp is nil if no response was received. nil means DefaultShouldRetry.
+	ShouldRetry func(err error, resp *http.Response) bool
+	// CUBudget, if set, is charged the CU cost of every attempt.
+	CUBudget *CUBudget
+	// CUCosts sets the cost of the getters leaving CU at 0, nil means DefaultCUCosts.
+	CUCosts CUCosts
+	// Cache, if set, stores the responses of the endpoints in CachePolicy.
+	// Cached responses are served without waiting on the limiter or spending CUs.
+	Cache Cache
+	// CachePolicy sets the TTL of every cached endpoint, nil means DefaultCachePolicy.
+	CachePolicy CachePolicy
+	Logger      *slog.Logger // nil means the package logger
+
+	// flight coalesces identical concurrent requests, set by the Client.
+	flight *singleflight.Group
+}
+
+// DefaultShouldRetry retries network errors and timeouts, 429 and 5xx responses
+// and HTML error pages. Client errors such as 400, 401, 403 and 404,
+// success:false envelopes and local errors, e.g. of the limiter, a bad URL
+// or a cancelled context, fail immediately.
+func DefaultShouldRetry(err error, resp *http.Response) bool {
+	if resp == nil {
+		return isNetworkError(err)
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) || errors.Is(err, ErrCUBudgetExceeded) {
+		return false
+	}
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		return apiErr.Temporary()
+	}
+	return resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= http.StatusInternalServerError
+}
+
+// isNetworkError reports whether err is a transient error of the connection.
+// The caller's context is checked before retrying, so a request timing out
+// is a network error even if it wraps context.DeadlineExceeded.
+func isNetworkError(err error) bool {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		if urlErr.Timeout() {
+			return true
+		}
+		err = urlErr.Err
+	}
+	if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr) || errors.Is(err, io.ErrUnexpectedEOF) || errors.Is(err, io.EOF)
 }
 
 var defaultGetterOption = &GetterOption{
@@ -99,12 +241,86 @@ var defaultGetterOption = &GetterOption{
 	MaxRetries:    1,
 }
 
+var defaultPagingGetterOption = &GetterOption{
+	RetryInterval:    500 * time.Millisecond,
+	MaxRetryInterval: 30 * time.Second,
+	Multiplier:       2,
+	Jitter:           true,
+	MaxRetries:       10,
+}
+
+// backoff returns how long to wait before retry number attempt (0 based).
+// A Retry-After or X-RateLimit-Reset header on resp takes precedence
+// when it asks for a longer wait.
+func (o *GetterOption) backoff(attempt int, resp *http.Response) time.Duration {
+	d := o.RetryInterval
+	if d <= 0 {
+		d = time.Second
+	}
+	if o.Multiplier > 1 {
+		d = time.Duration(float64(d) * math.Pow(o.Multiplier, float64(attempt)))
+	}
+	if o.MaxRetryInterval > 0 && (d > o.MaxRetryInterval || d <= 0) {
+		d = o.MaxRetryInterval
+	}
+	if o.Jitter && d > 0 {
+		d = time.Duration(rand.Int63n(int64(d) + 1))
+	}
+	if ra := retryAfter(resp); ra > d {
+		d = ra
+	}
+	return d
+}
+
+// retryAfter parses the server requested wait from resp headers.
+// Retry-After may be seconds or an HTTP date,
+// X-RateLimit-Reset may be seconds or a unix timestamp.
+func retryAfter(resp *http.Response) time.Duration {
+	if resp == nil {
+		return 0
+	}
+	if v := resp.Header.Get("Retry-After"); v != "" {
+		if secs, err := strconv.ParseInt(v, 10, 64); err == nil {
+			return time.Duration(secs) * time.Second
+		}
+		if t, err := http.ParseTime(v); err == nil {
+			return time.Until(t)
+		}
+	}
+	if v := resp.Header.Get("X-RateLimit-Reset"); v != "" {
+		if n, err := strconv.ParseInt(v, 10, 64); err == nil {
+			if n > 1e9 {
+				return time.Until(time.Unix(n, 0))
+			}
+			return time.Duration(n) * time.Second
+		}
+	}
+	return 0
+}
+
+// sleep waits for d or until ctx is done.
+func sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
+
 type SimpleGetter[D any] struct {
 	BaseURL           string
 	Path              string
 	Params            url.Values
 	Headers           map[string][]string
-	Limiter           *rate.Limiter
+	Limiter           Limiter
+	HTTPClient        *http.Client // nil means http.DefaultClient
+	CU                int64        // compute units charged per request, 0 means the cost of Path in Option.CUCosts
 	RespStatusHandler func(resp *http.Response) error
 	RespBodyUnmarshal func(body []byte) (D, error)
 	Option            *GetterOption
@@ -119,50 +335,132 @@ func (g *SimpleGetter[D]) Do(ctx context.Context) (D, error) {
 	if option == nil {
 		option = defaultGetterOption
 	}
-	retryInterval := option.RetryInterval
-	maxRetries := option.MaxRetries
-	if retryInterval == 0 {
-		retryInterval = time.Second
+	if d, ok := g.cached(); ok {
+		return d, nil
 	}
+	maxRetries := option.MaxRetries
 	if maxRetries == 0 {
 		maxRetries = 1
 	}
 	if maxRetries == 1 {
-		return g.do(ctx)
+		d, _, err := g.do(ctx)
+		return d, err
+	}
+	log := option.Logger
+	if log == nil {
+		log = logger
 	}
+	shouldRetry := option.ShouldRetry
+	if shouldRetry == nil {
+		shouldRetry = DefaultShouldRetry
+	}
+	var lastErr error
 	for i := 0; i < maxRetries; i++ {
-		d, err := g.do(ctx)
-		if err != nil {
-			time.Sleep(retryInterval)
-			logger.Error("solscan: failed to get response", "retrying", i+1, "error", err)
-			continue
+		d, resp, err := g.do(ctx)
+		if err == nil {
+			return d, nil
 		}
-		return d, nil
+		if ctx.Err() != nil {
+			return *new(D), ctx.Err()
+		}
+		if !shouldRetry(err, resp) {
+			return *new(D), err
+		}
+		lastErr = err
+		if i == maxRetries-1 {
+			break
+		}
+		wait := option.backoff(i, resp)
+		log.Error("solscan: failed to get response", "retrying", i+1, "wait", wait, "error", err)
+		if err := sleep(ctx, wait); err != nil {
+			return *new(D), err
+		}
+	}
+	return *new(D), fmt.Errorf("solscan: failed to get response after %d retries: %w", maxRetries, lastErr)
+}
+
+// do sends one request and reports its outcome to an adaptive limiter.
+// The returned response, if any, has its body closed
+// and is only meant for inspecting the status and headers.
+func (g *SimpleGetter[D]) do(ctx context.Context) (D, *http.Response, error) {
+	body, resp, sent, err := g.fetch(ctx)
+	var d D
+	if err == nil {
+		d, err = g.decode(body, resp)
+	}
+	if fb, ok := g.Limiter.(LimiterFeedback); ok && sent {
+		fb.Observe(err)
+	}
+	return d, resp, err
+}
+
+// fetch gets the response body. Identical concurrent requests of a Client are coalesced:
+// one of them is sent and every caller decodes its own copy of the body.
+// Solscan charges the sent request once, so only its caller spends CUs,
+// the budgets of the other callers are not charged.
+// sent reports whether this caller sent the request.
+func (g *SimpleGetter[D]) fetch(ctx context.Context) ([]byte, *http.Response, bool, error) {
+	if g.Option == nil || g.Option.flight == nil {
+		body, resp, err := g.request(ctx)
+		return body, resp, true, err
+	}
+	type response struct {
+		body []byte
+		resp *http.Response
+	}
+	sent := false // only read once fn returned
+	ch := g.Option.flight.DoChan(g.URL(), func() (any, error) {
+		sent = true
+		body, resp, err := g.request(ctx)
+		return response{body, resp}, err
+	})
+	select {
+	case r := <-ch:
+		if !sent && ctx.Err() == nil && (errors.Is(r.Err, context.Canceled) || errors.Is(r.Err, context.DeadlineExceeded)) {
+			// The caller sending the request gave up, not this one.
+			body, resp, err := g.request(ctx)
+			return body, resp, true, err
+		}
+		res := r.Val.(response)
+		body := res.body
+		if r.Shared {
+			body = bytes.Clone(body)
+		}
+		return body, res.resp, sent, r.Err
+	case <-ctx.Done():
+		return nil, nil, false, ctx.Err()
 	}
-	return *new(D), fmt.Errorf("solscan: failed to get response after %d retries", maxRetries)
 }
 
-func (g *SimpleGetter[D]) do(ctx context.Context) (D, error) {
+// request sends one request and returns the body of a successful response.
+func (g *SimpleGetter[D]) request(ctx context.Context) ([]byte, *http.Response, error) {
 	if g.Limiter != nil {
 		err := g.Limiter.Wait(ctx)
 		if err != nil {
-			return *new(D), err
+			return nil, nil, err
 		}
 	}
+	if err := spendCU(ctx, g.Option, g.cu()); err != nil {
+		return nil, nil, err
+	}
 	ul := fmt.Sprintf("%s/%s", g.BaseURL, strings.Trim(g.Path, "/"))
 	if len(g.Params) > 0 {
 		ul += "?" + g.Params.Encode()
 	}
 	req, err := http.NewRequestWithContext(ctx, "GET", ul, nil)
 	if err != nil {
-		return *new(D), err
+		return nil, nil, err
 	}
 	for k, v := range g.Headers {
 		req.Header[k] = v
 	}
-	resp, err := http.DefaultClient.Do(req)
+	httpClient := g.HTTPClient
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	resp, err := httpClient.Do(req)
 	if err != nil {
-		return *new(D), err
+		return nil, nil, err
 	}
 	defer resp.Body.Close()
 
@@ -173,45 +471,108 @@ func (g *SimpleGetter[D]) do(ctx context.Context) (D, error) {
 	}
 
 	if err != nil {
-		return *new(D), err
+		return nil, resp, err
 	}
 
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return *new(D), fmt.Errorf("solscan: can not read body: %s", err.Error())
+		return nil, resp, fmt.Errorf("solscan: can not read body: %s", err.Error())
 	}
+	return body, resp, nil
+}
 
+// decode unmarshals the body of resp and caches it if its endpoint is cached.
+func (g *SimpleGetter[D]) decode(body []byte, resp *http.Response) (D, error) {
+	d, err := g.unmarshal(body)
+	var apiErr *APIError
+	if errors.As(err, &apiErr) && apiErr.URL == "" {
+		apiErr.setRequest(resp)
+	}
+	if err == nil {
+		if ttl, ok := g.cacheTTL(); ok {
+			g.Option.Cache.Set(g.URL(), body, ttl)
+		}
+	}
+	return d, err
+}
+
+func (g *SimpleGetter[D]) unmarshal(body []byte) (D, error) {
 	if g.RespBodyUnmarshal != nil {
 		return g.RespBodyUnmarshal(body)
 	}
-
 	return DefaultRespBodyUnmarshal[D](body)
 }
 
-func CreateSliceDataFinishChecker[D any](pageSize int64) func(d []D) bool {
+// cu returns the compute units charged per request.
+func (g *SimpleGetter[D]) cu() int64 {
+	if g.CU != 0 || g.Option == nil {
+		return g.CU
+	}
+	return g.Option.CUCosts.cost(g.Path)
+}
+
+// cacheTTL returns the TTL of the response, ok is false if it is not cached.
+func (g *SimpleGetter[D]) cacheTTL() (ttl time.Duration, ok bool) {
+	if g.Option == nil || g.Option.Cache == nil {
+		return 0, false
+	}
+	return g.Option.CachePolicy.ttl(g.Path)
+}
+
+// cached returns the cached response, keyed by URL.
+// A body that can not be unmarshaled is a miss.
+func (g *SimpleGetter[D]) cached() (D, bool) {
+	if _, ok := g.cacheTTL(); !ok {
+		return *new(D), false
+	}
+	body, ok := g.Option.Cache.Get(g.URL())
+	if !ok {
+		return *new(D), false
+	}
+	d, err := g.unmarshal(body)
+	if err != nil {
+		return *new(D), false
+	}
+	return d, true
+}
+
+func CreateSliceDataFinishChecker[D any](pageSize int64) CcrtDataFinishChecker[[]D] {
 	return func(d []D) bool {
 		return len(d) < int(pageSize)
 	}
 }
 
-func CreateWithTotalItemsFinishChecker[D any](pageSize int64) func(d RespDataWithTotal[D]) bool {
+func CreateWithTotalItemsFinishChecker[D any](pageSize int64) CcrtDataFinishChecker[RespDataWithTotal[D]] {
 	return func(d RespDataWithTotal[D]) bool {
 		return len(d.Items) < int(pageSize)
 	}
 }
 
-func CreateWithTotalTransactionsDataFinishChecker(pageSize int64) func(d RespDataWithTotal[Transaction]) bool {
+func CreateWithTotalTransactionsDataFinishChecker(pageSize int64) CcrtDataFinishChecker[RespDataWithTotal[Transaction]] {
 	return func(d RespDataWithTotal[Transaction]) bool {
 		return len(d.Transactions) < int(pageSize)
 	}
 }
 
-func CreateWithTotalDataFinishChecker[D any](pageSize int64) func(d RespDataWithTotal[D]) bool {
+func CreateWithTotalDataFinishChecker[D any](pageSize int64) CcrtDataFinishChecker[RespDataWithTotal[D]] {
 	return func(d RespDataWithTotal[D]) bool {
 		return len(d.Data) < int(pageSize)
 	}
 }
 
+// ExtractTotal returns the total reported by an endpoint, for PagingParams.TotalExtractor.
+func ExtractTotal[D any](d RespDataWithTotal[D]) int64 {
+	return d.Total
+}
+
+// truncatedLen returns min(l, totalSize), a totalSize of 0 or less means no limit.
+func truncatedLen(l int, totalSize int64) int {
+	if totalSize <= 0 || int64(l) < totalSize {
+		return l
+	}
+	return int(totalSize)
+}
+
 func CreateSliceResultsHandler[D any](totalSize int64) func([][]D) ([]D, error) {
 	return func(results [][]D) ([]D, error) {
 		l := 0
@@ -222,7 +583,7 @@ func CreateSliceResultsHandler[D any](totalSize int64) func([][]D) ([]D, error)
 		for _, result := range results {
 			r = append(r, result...)
 		}
-		return r[:int(math.Min(float64(totalSize), float64(len(r))))], nil
+		return r[:truncatedLen(len(r), totalSize)], nil
 	}
 }
 
@@ -241,7 +602,7 @@ func CreateWithTotalItemsResultsHandler[D any](totalSize int64) func([]RespDataW
 			}
 		}
 		return RespDataWithTotal[D]{
-			Items: r[:int(math.Min(float64(totalSize), float64(len(r))))],
+			Items: r[:truncatedLen(len(r), totalSize)],
 			Total: total,
 		}, nil
 	}
@@ -262,7 +623,7 @@ func CreateWithTotalTransactionsResultsHandler(totalSize int64) func([]RespDataW
 			}
 		}
 		return RespDataWithTotal[Transaction]{
-			Transactions: r[:int(math.Min(float64(totalSize), float64(len(r))))],
+			Transactions: r[:truncatedLen(len(r), totalSize)],
 			Total:        total,
 		}, nil
 	}
@@ -283,7 +644,7 @@ func CreateWithTotalDataResultsHandler[D any](totalSize int64) func([]RespDataWi
 			}
 		}
 		return RespDataWithTotal[D]{
-			Data:  r[:int(math.Min(float64(totalSize), float64(len(r))))],
+			Data:  r[:truncatedLen(len(r), totalSize)],
 			Total: total,
 		}, nil
 	}
@@ -293,11 +654,23 @@ type CcrtDataFinishChecker[D any] func(d D) bool
 
 type CcrtResultsHandler[D any] func(results []D) (D, error)
 
+// CcrtGetter runs Getters, usually one per page, with at most MaxConcurrency
+// requests in flight. Once DataFinishChecker reports a page as the last one,
+// later pages are cancelled and dropped. Results are kept in Getters order.
 type CcrtGetter[D any] struct {
 	Getters           []Getter[D]
 	MaxConcurrency    int64
 	DataFinishChecker CcrtDataFinishChecker[D]
 	ResultsHandler    CcrtResultsHandler[D]
+	// PageHandler, if set, streams every result in Getters order as soon as it
+	// and all results before it are done. Results are then not buffered,
+	// and ResultsHandler, if set, is called with no results.
+	// PageHandler is never called concurrently.
+	PageHandler func(i int, d D) error
+	// PartialResults, if set, keeps going when a getter fails: the results of
+	// the others are returned with a PageErrors listing the failed getters by index.
+	PartialResults bool
+	Logger         *slog.Logger // nil means the package logger
 }
 
 func (g *CcrtGetter[D]) URL() string {
@@ -305,52 +678,165 @@ func (g *CcrtGetter[D]) URL() string {
 }
 
 func (g *CcrtGetter[D]) Do(ctx context.Context) (D, error) {
-	if g.MaxConcurrency == 0 {
-		g.MaxConcurrency = 1
+	maxConcurrency := int(g.MaxConcurrency)
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	log := g.Logger
+	if log == nil {
+		log = logger
 	}
 	l := len(g.Getters)
-	results := make([]D, l)
-	var isRespEmpty bool
-	for i := 0; i < l; i += int(g.MaxConcurrency) {
-		end := i + int(g.MaxConcurrency)
-		if end > l {
-			end = l
-		}
-		logger.Info("solscan: concurrency", "start", i, "end", end, "total", l, "url", g.Getters[i].URL())
-		group := g.Getters[i:end]
-		eg, ctx := errgroup.WithContext(ctx)
-		for j, simpleGetter := range group {
-			j := j
-			simpleGetter := simpleGetter
-			eg.Go(func() error {
-				d, err := simpleGetter.Do(ctx)
-				if err != nil {
-					return err
-				}
-				if g.DataFinishChecker != nil && g.DataFinishChecker(d) {
-					isRespEmpty = true
+	if l == 0 {
+		return g.handleResults(nil)
+	}
+	log.Info("solscan: concurrency", "total", l, "max", maxConcurrency, "url", g.Getters[0].URL())
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		last     = l - 1 // index of the last needed result
+		next     = 0     // index of the next result to deliver
+		results  = make([]D, l)
+		done     = make([]bool, l)
+		failed   = make([]error, l)
+		cancels  = make([]context.CancelFunc, l)
+		firstErr error
+	)
+	fail := func(err error) {
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	// deliver hands finished results over in order, mu must be held.
+	deliver := func() {
+		for next <= last && done[next] {
+			if g.PageHandler != nil && failed[next] == nil {
+				if err := g.PageHandler(next, results[next]); err != nil {
+					fail(err)
+					return
 				}
-				results[i+j] = d
-				return nil
-			})
+				results[next] = *new(D)
+			}
+			next++
 		}
-		err := eg.Wait()
-		if err != nil {
-			return *new(D), err
+	}
+
+	sem := make(chan struct{}, maxConcurrency)
+dispatch:
+	for i := 0; i < l; i++ {
+		select {
+		case sem <- struct{}{}:
+		case <-ctx.Done():
+			break dispatch
 		}
-		if isRespEmpty {
+		mu.Lock()
+		if i > last || firstErr != nil {
+			mu.Unlock()
+			<-sem
 			break
 		}
+		pageCtx, pageCancel := context.WithCancel(ctx)
+		cancels[i] = pageCancel
+		mu.Unlock()
+		wg.Add(1)
+		go func(i int, getter Getter[D]) {
+			defer wg.Done()
+			defer func() { <-sem }()
+			defer pageCancel()
+			d, err := getter.Do(pageCtx)
+			mu.Lock()
+			defer mu.Unlock()
+			if i > last || firstErr != nil {
+				return
+			}
+			if err != nil && g.PartialResults && ctx.Err() == nil {
+				failed[i] = err
+				done[i] = true
+				deliver()
+				return
+			}
+			if err != nil {
+				fail(err)
+				return
+			}
+			results[i] = d
+			done[i] = true
+			if g.DataFinishChecker != nil && g.DataFinishChecker(d) && i < last {
+				last = i
+				for _, c := range cancels[i+1:] {
+					if c != nil {
+						c()
+					}
+				}
+			}
+			deliver()
+		}(i, g.Getters[i])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return *new(D), firstErr
+	}
+	if next <= last {
+		return *new(D), ctx.Err()
+	}
+	var (
+		pageErrs PageErrors
+		ok       []D
+	)
+	for i := 0; i <= last; i++ {
+		if failed[i] != nil {
+			pageErrs = append(pageErrs, PageError{Page: int64(i), Err: failed[i]})
+		} else if g.PageHandler == nil {
+			ok = append(ok, results[i])
+		}
+	}
+	d, err := g.handleResults(ok)
+	if err == nil && len(pageErrs) > 0 {
+		err = pageErrs
+	}
+	return d, err
+}
+
+func (g *CcrtGetter[D]) handleResults(results []D) (D, error) {
+	if g.ResultsHandler == nil {
+		return *new(D), nil
 	}
 	return g.ResultsHandler(results)
 }
 
 type PagingParams[D any] struct {
-	StartPage         int64
-	TotalSize         int64
+	StartPage int64
+	// TotalSize is the number of items to fetch. 0 or less fetches all pages:
+	// the pages are planned from TotalExtractor after the first request if it
+	// reports a total, otherwise pages are fetched until DataFinishChecker
+	// reports the last page.
+	TotalSize int64
+	// MaxPages caps the number of pages fetched, 0 means no cap.
+	MaxPages          int64
 	MaxConcurrency    int64
 	DataFinishChecker CcrtDataFinishChecker[D]
 	ResultsHandler    CcrtResultsHandler[D]
+	// DataFinishCheckerCreator, if set, creates the DataFinishChecker
+	// from the page_size param, so a custom page size is honored.
+	DataFinishCheckerCreator func(pageSize int64) CcrtDataFinishChecker[D]
+	// PageSizes lists the page sizes accepted by the endpoint, nil accepts any.
+	PageSizes []int64
+	// TotalExtractor returns the total number of items reported by a page, 0 if unknown.
+	TotalExtractor func(d D) int64
+	// PageHandler, if set, streams pages in order as they complete,
+	// see CcrtGetter.PageHandler.
+	PageHandler func(page int64, d D) error
+	// PartialResults, if set, keeps going when pages fail: the results of the
+	// other pages are returned with a PageErrors listing the failed pages.
+	PartialResults bool
+	// Checkpoint, if set, makes the run resumable, see Checkpoint.
+	Checkpoint Checkpoint
 }
 
 type PagingGetter[D any] struct {
@@ -358,7 +844,9 @@ type PagingGetter[D any] struct {
 	Path         string
 	Params       url.Values
 	Headers      map[string][]string
-	Limiter      *rate.Limiter
+	Limiter      Limiter
+	HTTPClient   *http.Client
+	CU           int64
 	GetterOption *GetterOption
 	PagingParams *PagingParams[D]
 }
@@ -370,43 +858,268 @@ func (g *PagingGetter[D]) URL() string {
 func (g *PagingGetter[D]) Do(ctx context.Context) (D, error) {
 	if g.PagingParams == nil {
 		sg := SimpleGetter[D]{
-			BaseURL: g.BaseURL,
-			Path:    g.Path,
-			Params:  g.Params,
-			Headers: g.Headers,
-			Limiter: g.Limiter,
+			BaseURL:    g.BaseURL,
+			Path:       g.Path,
+			Params:     g.Params,
+			Headers:    g.Headers,
+			Limiter:    g.Limiter,
+			HTTPClient: g.HTTPClient,
+			CU:         g.CU,
+			Option:     g.GetterOption,
 		}
 		return sg.Do(ctx)
 	}
+	if cp := g.PagingParams.Checkpoint; cp != nil {
+		return g.doCheckpointed(ctx, cp)
+	}
+	return g.doPages(ctx)
+}
+
+// doPages fetches the pages described by PagingParams.
+func (g *PagingGetter[D]) doPages(ctx context.Context) (D, error) {
+	pageSize, err := g.pageSize()
+	if err != nil {
+		return *new(D), err
+	}
+	if create := g.PagingParams.DataFinishCheckerCreator; create != nil {
+		pp := *g.PagingParams
+		pp.DataFinishChecker = create(pageSize)
+		gg := *g
+		gg.PagingParams = &pp
+		g = &gg
+	}
+	if g.PagingParams.TotalSize <= 0 {
+		return g.fetchAll(ctx, pageSize)
+	}
+	pages := g.capPages(int64(math.Ceil(float64(g.PagingParams.TotalSize)/float64(pageSize))), 0)
+	startPage := g.PagingParams.StartPage
+	ccrt := g.newCcrtGetter(startPage, pages)
+	ccrt.ResultsHandler = g.PagingParams.ResultsHandler
+	if h := g.PagingParams.PageHandler; h != nil {
+		ccrt.PageHandler = func(i int, d D) error {
+			return h(int64(i)+startPage, d)
+		}
+	}
+	d, err := ccrt.Do(ctx)
+	var pageErrs PageErrors
+	if errors.As(err, &pageErrs) {
+		for i := range pageErrs {
+			pageErrs[i].Page += startPage
+		}
+	}
+	return d, err
+}
+
+// doCheckpointed resumes from the page saved in cp and saves every completed page.
+// On failure it returns the results fetched so far with a *CheckpointError.
+func (g *PagingGetter[D]) doCheckpointed(ctx context.Context, cp Checkpoint) (D, error) {
+	pageSize, err := g.pageSize()
+	if err != nil {
+		return *new(D), err
+	}
+	state, err := loadCheckpoint(cp, checkpointQuery(g.Path, g.Params, "page"))
+	if err != nil {
+		return *new(D), err
+	}
+	orig := g.PagingParams
+	handle := func(results []D) (D, error) {
+		if orig.ResultsHandler == nil {
+			return *new(D), nil
+		}
+		return orig.ResultsHandler(results)
+	}
+	pp := *orig
+	if state.NextPage > pp.StartPage {
+		completed := state.NextPage - pp.StartPage
+		if pp.TotalSize > 0 {
+			pp.TotalSize -= completed * pageSize
+			state.Done = state.Done || pp.TotalSize <= 0
+		}
+		if pp.MaxPages > 0 {
+			pp.MaxPages -= completed
+			state.Done = state.Done || pp.MaxPages <= 0
+		}
+		pp.StartPage = state.NextPage
+	}
+	if state.Done {
+		return handle(nil)
+	}
+
+	var (
+		results []D
+		next    = pp.StartPage
+		gap     bool // a page before failed, so the checkpoint can not move on
+	)
+	pp.ResultsHandler = nil
+	pp.PageHandler = func(page int64, d D) error {
+		if orig.PageHandler != nil {
+			if err := orig.PageHandler(page, d); err != nil {
+				return err
+			}
+		} else {
+			results = append(results, d)
+		}
+		gap = gap || page != next
+		next = page + 1
+		if gap {
+			return nil
+		}
+		state.NextPage = next
+		return cp.Save(state)
+	}
+	gg := *g
+	gg.PagingParams = &pp
+	_, err = gg.doPages(ctx)
+	d, handleErr := handle(results)
+	if err != nil {
+		return d, &CheckpointError{State: state, Err: err}
+	}
+	state.Done = true
+	if err := cp.Save(state); err != nil {
+		return d, err
+	}
+	return d, handleErr
+}
+
+// pageSize returns the page_size param, checked against PageSizes.
+func (g *PagingGetter[D]) pageSize() (int64, error) {
 	pageSize, err := strconv.ParseInt(g.Params.Get("page_size"), 10, 64)
 	if err != nil {
-		return *new(D), fmt.Errorf("solscan: can not get page_size: %s", err.Error())
+		return 0, fmt.Errorf("solscan: can not get page_size: %s", err.Error())
+	}
+	if pageSize <= 0 {
+		return 0, fmt.Errorf("%w: %d", ErrInvalidPageSize, pageSize)
+	}
+	if len(g.PagingParams.PageSizes) > 0 && !slices.Contains(g.PagingParams.PageSizes, pageSize) {
+		return 0, fmt.Errorf("%w: %d, must be one of %v", ErrInvalidPageSize, pageSize, g.PagingParams.PageSizes)
+	}
+	return pageSize, nil
+}
+
+// capPages limits pages so that fetched+pages does not exceed MaxPages.
+func (g *PagingGetter[D]) capPages(pages, fetched int64) int64 {
+	if maxPages := g.PagingParams.MaxPages; maxPages > 0 && fetched+pages > maxPages {
+		pages = maxPages - fetched
+	}
+	if pages < 0 {
+		pages = 0
 	}
-	pages := int64(math.Ceil(float64(g.PagingParams.TotalSize) / float64(pageSize)))
+	return pages
+}
+
+// newCcrtGetter creates a CcrtGetter fetching pages [startPage, startPage+pages).
+func (g *PagingGetter[D]) newCcrtGetter(startPage, pages int64) *CcrtGetter[D] {
 	getters := make([]Getter[D], pages)
 	for i := int64(0); i < pages; i++ {
-		p := url.Values{}
-		for k, v := range g.Params {
-			for _, vv := range v {
-				p.Add(k, vv)
-			}
+		p := cloneParams(g.Params)
+		p.Set("page", strconv.FormatInt(i+startPage, 10))
+		getters[i] = &SimpleGetter[D]{
+			BaseURL:    g.BaseURL,
+			Path:       g.Path,
+			Params:     p,
+			Headers:    g.Headers,
+			Limiter:    g.Limiter,
+			HTTPClient: g.HTTPClient,
+			CU:         g.CU,
+			Option:     g.GetterOption,
 		}
-		p.Set("page", strconv.FormatInt(i+g.PagingParams.StartPage, 10))
-		sg := &SimpleGetter[D]{
-			BaseURL: g.BaseURL,
-			Path:    g.Path,
-			Params:  p,
-			Headers: g.Headers,
-			Limiter: g.Limiter,
-			Option:  g.GetterOption,
-		}
-		getters[i] = sg
 	}
 	ccrt := &CcrtGetter[D]{
 		Getters:           getters,
 		MaxConcurrency:    g.PagingParams.MaxConcurrency,
 		DataFinishChecker: g.PagingParams.DataFinishChecker,
-		ResultsHandler:    g.PagingParams.ResultsHandler,
+		PartialResults:    g.PagingParams.PartialResults,
+	}
+	if g.GetterOption != nil {
+		ccrt.Logger = g.GetterOption.Logger
+	}
+	return ccrt
+}
+
+// fetchAll fetches pages until the endpoint is exhausted or MaxPages is reached.
+func (g *PagingGetter[D]) fetchAll(ctx context.Context, pageSize int64) (D, error) {
+	pp := g.PagingParams
+	if pp.DataFinishChecker == nil && pp.TotalExtractor == nil && pp.MaxPages <= 0 {
+		return *new(D), fmt.Errorf("solscan: fetching all pages needs a DataFinishChecker, a TotalExtractor or MaxPages")
+	}
+	var (
+		results  []D
+		fetched  int64
+		finished bool
+		total    int64
+		pageErrs PageErrors
+	)
+	run := func(pages int64) error {
+		startPage := pp.StartPage + fetched
+		ccrt := g.newCcrtGetter(startPage, pages)
+		delivered := int64(0)
+		ccrt.PageHandler = func(i int, d D) error {
+			delivered++
+			if pp.DataFinishChecker != nil && pp.DataFinishChecker(d) {
+				finished = true
+			}
+			if pp.TotalExtractor != nil && total == 0 {
+				total = pp.TotalExtractor(d)
+			}
+			if pp.PageHandler != nil {
+				return pp.PageHandler(startPage+int64(i), d)
+			}
+			results = append(results, d)
+			return nil
+		}
+		_, err := ccrt.Do(ctx)
+		var errs PageErrors
+		if errors.As(err, &errs) {
+			for _, pe := range errs {
+				pe.Page += startPage
+				pageErrs = append(pageErrs, pe)
+			}
+			err = nil
+		}
+		if err != nil {
+			return err
+		}
+		fetched += delivered + int64(len(errs))
+		// A window without a single delivered page gives up, rather than
+		// paging on while every request fails.
+		if delivered+int64(len(errs)) < pages || delivered == 0 {
+			finished = true
+		}
+		return nil
+	}
+
+	if err := run(g.capPages(1, 0)); err != nil {
+		return *new(D), err
+	}
+	if !finished && total > 0 {
+		planned := int64(math.Ceil(float64(total)/float64(pageSize))) - (pp.StartPage - 1)
+		if err := run(g.capPages(planned-fetched, fetched)); err != nil {
+			return *new(D), err
+		}
+		finished = true
+	}
+	window := pp.MaxConcurrency
+	if window <= 0 {
+		window = 1
+	}
+	for !finished {
+		pages := g.capPages(window, fetched)
+		if pages == 0 {
+			break
+		}
+		if err := run(pages); err != nil {
+			return *new(D), err
+		}
+	}
+	var (
+		d   D
+		err error
+	)
+	if pp.ResultsHandler != nil {
+		d, err = pp.ResultsHandler(results)
+	}
+	if err == nil && len(pageErrs) > 0 {
+		err = pageErrs
 	}
-	return ccrt.Do(ctx)
+	return d, err
 }
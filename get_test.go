@@ -0,0 +1,608 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestGetterOptionBackoff(t *testing.T) {
+	opt := &GetterOption{
+		RetryInterval:    100 * time.Millisecond,
+		MaxRetryInterval: time.Second,
+		Multiplier:       2,
+	}
+	want := []time.Duration{100, 200, 400, 800, 1000, 1000}
+	for i, w := range want {
+		if d := opt.backoff(i, nil); d != w*time.Millisecond {
+			t.Fatalf("backoff(%d) = %v, want %v", i, d, w*time.Millisecond)
+		}
+	}
+	opt.Jitter = true
+	for i := 0; i < 100; i++ {
+		if d := opt.backoff(3, nil); d < 0 || d > 800*time.Millisecond {
+			t.Fatalf("jittered backoff out of range: %v", d)
+		}
+	}
+	resp := &http.Response{Header: http.Header{"Retry-After": {"5"}}}
+	if d := opt.backoff(0, resp); d != 5*time.Second {
+		t.Fatalf("backoff with Retry-After = %v, want 5s", d)
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	reset := time.Now().Add(10 * time.Second).Unix()
+	cases := []struct {
+		header http.Header
+		min    time.Duration
+		max    time.Duration
+	}{
+		{http.Header{}, 0, 0},
+		{http.Header{"Retry-After": {"3"}}, 3 * time.Second, 3 * time.Second},
+		{http.Header{"X-Ratelimit-Reset": {"2"}}, 2 * time.Second, 2 * time.Second},
+		{http.Header{"X-Ratelimit-Reset": {strconv.FormatInt(reset, 10)}}, 8 * time.Second, 10 * time.Second},
+	}
+	for _, c := range cases {
+		d := retryAfter(&http.Response{Header: c.header})
+		if d < c.min || d > c.max {
+			t.Fatalf("retryAfter(%v) = %v, want [%v, %v]", c.header, d, c.min, c.max)
+		}
+	}
+}
+
+func TestSimpleGetterRetryStopsOnCancel(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	sg := SimpleGetter[ChainInfo]{
+		BaseURL: srv.URL,
+		Path:    "chaininfo",
+		Option:  &GetterOption{RetryInterval: time.Minute, MaxRetries: 100},
+	}
+	start := time.Now()
+	_, err := sg.Do(ctx)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want context.DeadlineExceeded", err)
+	}
+	if time.Since(start) > 5*time.Second {
+		t.Fatal("retry loop did not abort on context cancellation")
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("calls = %d, want 1", calls.Load())
+	}
+}
+
+func TestSimpleGetterRetrySucceeds(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) < 3 {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"blockHeight":7}}`))
+	}))
+	defer srv.Close()
+	sg := SimpleGetter[ChainInfo]{
+		BaseURL: srv.URL,
+		Path:    "chaininfo",
+		Option:  &GetterOption{RetryInterval: time.Millisecond, Multiplier: 2, MaxRetries: 5},
+	}
+	info, err := sg.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if info.BlockHeight != 7 || calls.Load() != 3 {
+		t.Fatalf("block height = %d, calls = %d", info.BlockHeight, calls.Load())
+	}
+}
+
+func TestSimpleGetterCoalescing(t *testing.T) {
+	var calls atomic.Int64
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"address":"a"}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"))
+	ctx := context.Background()
+
+	errs := make(chan error, 5)
+	for range 5 {
+		go func() {
+			meta, err := client.TokenMeta(ctx, "a")
+			if err == nil && meta.Address != "a" {
+				err = errors.New("address = " + meta.Address)
+			}
+			errs <- err
+		}()
+	}
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+	for range 5 {
+		if err := <-errs; err != nil {
+			t.Fatal(err)
+		}
+	}
+	if n := calls.Load(); n != 1 {
+		t.Fatalf("calls = %d, want 1", n)
+	}
+	if used := client.CUBudget().Used(); used != DEFAULT_CU_COST {
+		t.Fatalf("used = %d, want %d", used, DEFAULT_CU_COST)
+	}
+}
+
+func TestSimpleGetterCoalescingLeaderCanceled(t *testing.T) {
+	var calls atomic.Int64
+	release := make(chan struct{})
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		select {
+		case <-release:
+		case <-r.Context().Done():
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{"address":"a"}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"))
+
+	leaderCtx, cancel := context.WithCancel(context.Background())
+	leaderErr := make(chan error, 1)
+	go func() {
+		_, err := client.TokenMeta(leaderCtx, "a")
+		leaderErr <- err
+	}()
+	for calls.Load() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	followerErr := make(chan error, 1)
+	go func() {
+		_, err := client.TokenMeta(context.Background(), "a")
+		followerErr <- err
+	}()
+	time.Sleep(20 * time.Millisecond)
+	cancel()
+	if err := <-leaderErr; !errors.Is(err, context.Canceled) {
+		t.Fatalf("leader err = %v, want context.Canceled", err)
+	}
+	close(release)
+	if err := <-followerErr; err != nil {
+		t.Fatalf("follower err = %v", err)
+	}
+	if n := calls.Load(); n != 2 {
+		t.Fatalf("calls = %d, want 2", n)
+	}
+}
+
+func TestConcurrentIteratorsDoNotShareItems(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		time.Sleep(50 * time.Millisecond)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		data := []Transfer{}
+		for i := (page - 1) * 10; i < page*10 && i < 25; i++ {
+			data = append(data, Transfer{TransID: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithLimiter(rate.NewLimiter(rate.Inf, 1)))
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	for range 2 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			it := client.AccountTransfersIter(context.Background(), "addr", &AccountTransfersParams{PageSize: LargePageSize10})
+			n := 0
+			for it.Next() {
+				if id := it.Item().TransID; id != strconv.Itoa(n) {
+					errs <- fmt.Errorf("item %d = %q", n, id)
+					return
+				}
+				n++
+			}
+			if it.Err() != nil {
+				errs <- it.Err()
+			} else if n != 25 {
+				errs <- fmt.Errorf("items = %d, want 25", n)
+			}
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if n := calls.Load(); n != 3 {
+		t.Fatalf("calls = %d, want 3 coalesced by both iterators", n)
+	}
+}
+
+func TestSimpleGetterRetryClassification(t *testing.T) {
+	var calls atomic.Int64
+	status := http.StatusUnauthorized
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+	sg := SimpleGetter[ChainInfo]{
+		BaseURL: srv.URL,
+		Path:    "chaininfo",
+		Option:  &GetterOption{RetryInterval: time.Millisecond, MaxRetries: 3},
+	}
+	_, err := sg.Do(context.Background())
+	if !errors.Is(err, Err401) {
+		t.Fatalf("err = %v, want Err401", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("401 was retried, calls = %d", calls.Load())
+	}
+
+	calls.Store(0)
+	status = http.StatusInternalServerError
+	_, err = sg.Do(context.Background())
+	if !errors.Is(err, Err500) {
+		t.Fatalf("err = %v, want wrapped Err500", err)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls = %d, want 3", calls.Load())
+	}
+}
+
+func TestDefaultShouldRetry(t *testing.T) {
+	limiter := rate.NewLimiter(rate.Every(time.Hour), 1)
+	limiter.Allow()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	limiterErr := limiter.Wait(ctx)
+	if limiterErr == nil {
+		t.Fatal("limiter did not fail")
+	}
+	opErr := &net.OpError{Op: "dial", Net: "tcp", Err: errors.New("connection refused")}
+	for _, tc := range []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"network error", &url.Error{Op: "Get", URL: "u", Err: opErr}, true},
+		{"unexpected eof", &url.Error{Op: "Get", URL: "u", Err: io.ErrUnexpectedEOF}, true},
+		{"timeout", &url.Error{Op: "Get", URL: "u", Err: context.DeadlineExceeded}, true},
+		{"bad url", &url.Error{Op: "Get", URL: "u", Err: errors.New("unsupported protocol scheme")}, false},
+		{"cancelled", &url.Error{Op: "Get", URL: "u", Err: context.Canceled}, false},
+		{"limiter", limiterErr, false},
+		{"cu budget", ErrCUBudgetExceeded, false},
+	} {
+		if got := DefaultShouldRetry(tc.err, nil); got != tc.want {
+			t.Errorf("%s: DefaultShouldRetry(%v) = %v, want %v", tc.name, tc.err, got, tc.want)
+		}
+	}
+}
+
+func TestSimpleGetterUnsuccessfulEnvelope(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("X-Request-Id", "req-2")
+		w.Write([]byte(`{"success":false,"errors":{"code":1001,"message":"invalid address"}}`))
+	}))
+	defer srv.Close()
+	sg := SimpleGetter[TokenMeta]{
+		BaseURL: srv.URL,
+		Path:    "token/meta",
+		Params:  url.Values{"address": {"abc"}},
+		Option:  &GetterOption{RetryInterval: time.Millisecond, MaxRetries: 3},
+	}
+	_, err := sg.Do(context.Background())
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || !errors.Is(err, ErrUnsuccessful) {
+		t.Fatalf("err = %v, want *APIError matching ErrUnsuccessful", err)
+	}
+	if apiErr.Code != 1001 || apiErr.Message != "invalid address" || apiErr.RequestID != "req-2" || apiErr.URL == "" {
+		t.Fatalf("unexpected APIError: %+v", apiErr)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("unsuccessful envelope was retried, calls = %d", calls.Load())
+	}
+}
+
+func TestExportBodyUnmarshal(t *testing.T) {
+	csv := []byte("block_id,trans_id\n1,abc\n")
+	if b, err := ExportBodyUnmarshal(csv); err != nil || string(b) != string(csv) {
+		t.Fatalf("csv body: %s, %v", b, err)
+	}
+	_, err := ExportBodyUnmarshal([]byte(`{"success":false,"errors":{"code":1,"message":"too many rows"}}`))
+	if !errors.Is(err, ErrUnsuccessful) {
+		t.Fatalf("err = %v, want ErrUnsuccessful", err)
+	}
+	if _, err := DefaultRespBodyUnmarshal[ChainInfo]([]byte(`{"data":{"blockHeight":1}}`)); err != nil {
+		t.Fatalf("envelope without success field: %v", err)
+	}
+}
+
+type fakePageGetter struct {
+	page     int
+	size     int
+	delay    time.Duration
+	err      error
+	canceled *atomic.Int64
+}
+
+func (g *fakePageGetter) Do(ctx context.Context) ([]int, error) {
+	select {
+	case <-time.After(g.delay):
+	case <-ctx.Done():
+		g.canceled.Add(1)
+		return nil, ctx.Err()
+	}
+	if g.err != nil {
+		return nil, g.err
+	}
+	d := make([]int, g.size)
+	for i := range d {
+		d[i] = g.page*100 + i
+	}
+	return d, nil
+}
+
+func (g *fakePageGetter) URL() string {
+	return ""
+}
+
+func TestCcrtGetterStopsAtShortPage(t *testing.T) {
+	var canceled atomic.Int64
+	sizes := []int{3, 3, 1, 3, 3, 3}
+	delays := []time.Duration{30, 10, 0, 60000, 60000, 60000}
+	getters := make([]Getter[[]int], len(sizes))
+	for i := range sizes {
+		getters[i] = &fakePageGetter{page: i, size: sizes[i], delay: delays[i] * time.Millisecond, canceled: &canceled}
+	}
+	g := &CcrtGetter[[]int]{
+		Getters:           getters,
+		MaxConcurrency:    4,
+		DataFinishChecker: CreateSliceDataFinishChecker[int](3),
+		ResultsHandler:    CreateSliceResultsHandler[int](100),
+	}
+	start := time.Now()
+	res, err := g.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if time.Since(start) > 10*time.Second {
+		t.Fatal("in-flight pages after the short page were not cancelled")
+	}
+	want := []int{0, 1, 2, 100, 101, 102, 200}
+	if len(res) != len(want) {
+		t.Fatalf("results = %v, want %v", res, want)
+	}
+	for i := range want {
+		if res[i] != want[i] {
+			t.Fatalf("results = %v, want %v", res, want)
+		}
+	}
+	if canceled.Load() == 0 {
+		t.Fatal("no page was cancelled")
+	}
+}
+
+func TestCcrtGetterStreamsInOrder(t *testing.T) {
+	var canceled atomic.Int64
+	n := 8
+	getters := make([]Getter[[]int], n)
+	for i := range getters {
+		getters[i] = &fakePageGetter{page: i, size: 2, delay: time.Duration(n-i) * 3 * time.Millisecond, canceled: &canceled}
+	}
+	var order []int
+	g := &CcrtGetter[[]int]{
+		Getters:        getters,
+		MaxConcurrency: 3,
+		ResultsHandler: CreateSliceResultsHandler[int](100),
+		PageHandler: func(i int, d []int) error {
+			if d[0] != i*100 {
+				t.Errorf("page %d got %v", i, d)
+			}
+			order = append(order, i)
+			return nil
+		},
+	}
+	res, err := g.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(res) != 0 {
+		t.Fatalf("streamed results were buffered: %v", res)
+	}
+	for i := range order {
+		if order[i] != i {
+			t.Fatalf("delivery order = %v", order)
+		}
+	}
+	if len(order) != n {
+		t.Fatalf("delivered %d pages, want %d", len(order), n)
+	}
+}
+
+func TestCcrtGetterError(t *testing.T) {
+	var canceled atomic.Int64
+	boom := errors.New("boom")
+	getters := []Getter[[]int]{
+		&fakePageGetter{page: 0, size: 2, canceled: &canceled},
+		&fakePageGetter{page: 1, size: 2, err: boom, canceled: &canceled},
+		&fakePageGetter{page: 2, size: 2, delay: time.Minute, canceled: &canceled},
+	}
+	g := &CcrtGetter[[]int]{
+		Getters:        getters,
+		MaxConcurrency: 3,
+		ResultsHandler: CreateSliceResultsHandler[int](100),
+	}
+	if _, err := g.Do(context.Background()); !errors.Is(err, boom) {
+		t.Fatalf("err = %v, want boom", err)
+	}
+}
+
+func TestPagingGetterFetchAll(t *testing.T) {
+	var calls atomic.Int64
+	srv := newTransfersServer(t, 250, &calls)
+	client := NewClient(WithProBaseURL(srv.URL))
+	transfers, err := client.AccountTransfersPagingQuery(context.Background(), 1, 0, 3, "addr", &AccountTransfersParams{PageSize: LargePageSize100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 250 {
+		t.Fatalf("transfers = %d, want 250", len(transfers))
+	}
+	if calls.Load() > 6 {
+		t.Fatalf("calls = %d, fetched too many pages", calls.Load())
+	}
+
+	calls.Store(0)
+	client = NewClient(WithProBaseURL(srv.URL), WithMaxPages(2))
+	transfers, err = client.AccountTransfersPagingQuery(context.Background(), 1, 0, 3, "addr", &AccountTransfersParams{PageSize: LargePageSize100})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 200 || calls.Load() != 2 {
+		t.Fatalf("transfers = %d, calls = %d, want 200 and 2", len(transfers), calls.Load())
+	}
+}
+
+func TestPagingGetterFetchAllWithTotal(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		items := []TokenHolder{}
+		for i := (page - 1) * 40; i < page*40 && i < 95; i++ {
+			items = append(items, TokenHolder{Rank: int64(i + 1)})
+		}
+		json.NewEncoder(w).Encode(RespData[RespDataWithTotal[TokenHolder]]{
+			Success: true,
+			Data:    RespDataWithTotal[TokenHolder]{Items: items, Total: 95},
+		})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL))
+	holders, err := client.TokenHoldersPagingQuery(context.Background(), 1, 0, 2, "token", &TokenHoldersParams{PageSize: SmallPageSize40})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(holders.Items) != 95 || holders.Total != 95 {
+		t.Fatalf("holders = %d, total = %d", len(holders.Items), holders.Total)
+	}
+	if calls.Load() != 3 {
+		t.Fatalf("calls = %d, want 3 planned from the reported total", calls.Load())
+	}
+}
+
+func TestPagingGetterCustomPageSize(t *testing.T) {
+	var calls atomic.Int64
+	srv := newTransfersServer(t, 45, &calls)
+	client := NewClient(WithProBaseURL(srv.URL))
+	transfers, err := client.AccountTransfersPagingQuery(context.Background(), 1, 0, 2, "addr", &AccountTransfersParams{PageSize: LargePageSize10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 45 {
+		t.Fatalf("transfers = %d, want 45", len(transfers))
+	}
+
+	calls.Store(0)
+	_, err = client.AccountTransfersPagingQuery(context.Background(), 1, 100, 2, "addr", &AccountTransfersParams{PageSize: 50})
+	if !errors.Is(err, ErrInvalidPageSize) {
+		t.Fatalf("err = %v, want ErrInvalidPageSize", err)
+	}
+	if calls.Load() != 0 {
+		t.Fatalf("calls = %d, want none for an invalid page size", calls.Load())
+	}
+}
+
+func TestCcrtGetterPartialResults(t *testing.T) {
+	var canceled atomic.Int64
+	getters := make([]Getter[[]int], 4)
+	for i := range getters {
+		getters[i] = &fakePageGetter{page: i, size: 2, canceled: &canceled}
+	}
+	getters[1].(*fakePageGetter).err = Err500
+	g := &CcrtGetter[[]int]{
+		Getters:           getters,
+		MaxConcurrency:    2,
+		DataFinishChecker: CreateSliceDataFinishChecker[int](2),
+		ResultsHandler:    CreateSliceResultsHandler[int](0),
+		PartialResults:    true,
+	}
+	res, err := g.Do(context.Background())
+	var pageErrs PageErrors
+	if !errors.As(err, &pageErrs) || !errors.Is(err, Err500) {
+		t.Fatalf("err = %v, want PageErrors wrapping Err500", err)
+	}
+	if pages := pageErrs.Pages(); len(pages) != 1 || pages[0] != 1 {
+		t.Fatalf("failed pages = %v, want [1]", pages)
+	}
+	if len(res) != 6 || res[2] != 200 {
+		t.Fatalf("results = %v, want pages 0, 2 and 3", res)
+	}
+}
+
+func TestPagingGetterPartialResults(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		if page == 2 {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data := []Transfer{}
+		for i := (page - 1) * 10; i < page*10 && i < 45; i++ {
+			data = append(data, Transfer{TransID: strconv.Itoa(i)})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithPartialResults())
+	transfers, err := client.AccountTransfersPagingQuery(context.Background(), 1, 0, 2, "addr", &AccountTransfersParams{PageSize: LargePageSize10})
+	var pageErrs PageErrors
+	if !errors.As(err, &pageErrs) {
+		t.Fatalf("err = %v, want PageErrors", err)
+	}
+	if pages := pageErrs.Pages(); len(pages) != 1 || pages[0] != 2 {
+		t.Fatalf("failed pages = %v, want [2]", pages)
+	}
+	if len(transfers) != 35 {
+		t.Fatalf("transfers = %d, want 35", len(transfers))
+	}
+
+	client = NewClient(WithProBaseURL(srv.URL))
+	if _, err := client.AccountTransfersPagingQuery(context.Background(), 1, 0, 2, "addr", &AccountTransfersParams{PageSize: LargePageSize10}); !errors.Is(err, Err400) || errors.As(err, &pageErrs) {
+		t.Fatalf("err = %v, want a plain Err400 without WithPartialResults", err)
+	}
+}
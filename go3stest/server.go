@@ -0,0 +1,420 @@
+// Package go3stest provides an in-process fake of the Solscan APIs used by go3s.Client,
+// for testing code depending on the client without network access.
+//
+//	srv := go3stest.NewServer()
+//	defer srv.Close()
+//	srv.AddAccountTransfers(address, transfers...)
+//	srv.Inject(go3stest.Fault{Path: "/account/transfer", Status: 429, Times: 1})
+//	client := srv.Client()
+package go3stest
+
+import (
+	"cmp"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dwdwow/go3s"
+	"golang.org/x/time/rate"
+)
+
+// PRO_PATH_PREFIX is the path the fake serves the Pro API under.
+const PRO_PATH_PREFIX = "/v2.0"
+
+type shape int
+
+const (
+	shapeObject       shape = iota // data is the object
+	shapeList                      // data is the list
+	shapeItems                     // data is {items, total}
+	shapeData                      // data is {data, total}
+	shapeTransactions              // data is {transactions, total}
+	shapeRaw                       // the body is the raw data, e.g. exported CSV
+)
+
+type endpoint struct {
+	key    string // param selecting the seeded data, "" if there is one for all requests
+	shape  shape
+	limit  string // param limiting a list without pages, e.g. "limit"
+	cursor string // json field of the item the before param points to
+}
+
+// endpoints lists the endpoints of go3s.Client by path, Pro API paths without PRO_PATH_PREFIX.
+var endpoints = map[string]endpoint{
+	"/chaininfo":               {shape: shapeObject},
+	"/account/transfer":        {key: "address", shape: shapeList},
+	"/account/token-accounts":  {key: "address", shape: shapeList},
+	"/account/defi/activities": {key: "address", shape: shapeList},
+	"/account/balance_change":  {key: "address", shape: shapeList},
+	"/account/transactions":    {key: "address", shape: shapeList, limit: "limit", cursor: "tx_hash"},
+	"/account/stake":           {key: "address", shape: shapeList},
+	"/account/detail":          {key: "address", shape: shapeObject},
+	"/account/reward/export":   {key: "address", shape: shapeRaw},
+	"/account/transfer/export": {key: "address", shape: shapeRaw},
+	"/token/transfer":          {key: "address", shape: shapeList},
+	"/token/defi/activities":   {key: "address", shape: shapeList},
+	"/token/markets":           {key: "token[]", shape: shapeList},
+	"/token/list":              {shape: shapeList},
+	"/token/trending":          {shape: shapeList, limit: "limit"},
+	"/token/price":             {key: "address", shape: shapeList},
+	"/token/holders":           {key: "address", shape: shapeItems},
+	"/token/meta":              {key: "address", shape: shapeObject},
+	"/token/top":               {shape: shapeList},
+	"/nft/news":                {shape: shapeData},
+	"/nft/activities":          {shape: shapeList},
+	"/nft/collection/lists":    {shape: shapeList},
+	"/nft/collection/items":    {key: "collection", shape: shapeList},
+	"/transaction/last":        {shape: shapeList, limit: "limit"},
+	"/transaction/detail":      {key: "tx", shape: shapeObject},
+	"/transaction/actions":     {key: "tx", shape: shapeObject},
+	"/block/last":              {shape: shapeList, limit: "limit"},
+	"/block/transactions":      {key: "block", shape: shapeTransactions},
+	"/block/detail":            {key: "block", shape: shapeObject},
+	"/market/list":             {shape: shapeList},
+	"/market/info":             {key: "address", shape: shapeObject},
+	"/market/volume":           {key: "address", shape: shapeObject},
+	"/monitor/usage":           {shape: shapeObject},
+}
+
+// Fault makes matching requests fail or slow down.
+type Fault struct {
+	Path    string        // e.g. "/account/transfer", "" matches every path
+	Status  int           // e.g. 429 or 500, 0 answers normally after Latency
+	Latency time.Duration // delay before answering
+	// RetryAfter, if set, is sent in the Retry-After header of failed requests.
+	RetryAfter time.Duration
+	// Times is the number of requests affected, 0 means every request.
+	Times int
+}
+
+// Server is a fake of the Solscan Pro v2 and public APIs, serving seeded data.
+// List endpoints honor page and page_size, limit, the before cursor,
+// sort_by and sort_order, and the block_time range.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	data     map[string]map[string]any // path, key, then []map[string]any, json.RawMessage or []byte
+	faults   []*Fault
+	requests map[string]int
+}
+
+// NewServer starts a Server, close it when done.
+func NewServer() *Server {
+	s := &Server{
+		data:     map[string]map[string]any{},
+		requests: map[string]int{},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.handle))
+	return s
+}
+
+// Client creates a client of the server without rate limits. opts are applied last.
+func (s *Server) Client(opts ...go3s.Option) *go3s.Client {
+	return go3s.NewClient(append([]go3s.Option{
+		go3s.WithToken("go3stest"),
+		go3s.WithProBaseURL(s.URL + PRO_PATH_PREFIX),
+		go3s.WithPublicBaseURL(s.URL),
+		go3s.WithLimiter(rate.NewLimiter(rate.Inf, 1)),
+	}, opts...)...)
+}
+
+// Seed sets the data served by path for key, the value of the endpoint param selecting
+// the data, e.g. the address, or "" for endpoints without one. Values of multi value
+// params are joined by commas. Lists are seeded as slices, replacing earlier ones.
+func (s *Server) Seed(path, key string, data any) {
+	s.seed(path, key, data, false)
+}
+
+// Add appends the items of the slice data to the list served by path for key, see Seed.
+func (s *Server) Add(path, key string, data any) {
+	s.seed(path, key, data, true)
+}
+
+func (s *Server) seed(path, key string, data any, appendItems bool) {
+	ep, ok := endpoints[path]
+	if !ok {
+		panic(fmt.Sprintf("go3stest: unknown path %s", path))
+	}
+	var v any
+	switch ep.shape {
+	case shapeRaw:
+		v = data
+	case shapeObject:
+		b, err := json.Marshal(data)
+		if err != nil {
+			panic(fmt.Sprintf("go3stest: can not marshal %s data: %s", path, err.Error()))
+		}
+		v = json.RawMessage(b)
+	default:
+		var items []map[string]any
+		b, err := json.Marshal(data)
+		if err == nil {
+			err = json.Unmarshal(b, &items)
+		}
+		if err != nil {
+			panic(fmt.Sprintf("go3stest: %s data must be a slice of structs: %s", path, err.Error()))
+		}
+		v = items
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.data[path] == nil {
+		s.data[path] = map[string]any{}
+	}
+	if items, ok := s.data[path][key].([]map[string]any); ok && appendItems {
+		v = append(items, v.([]map[string]any)...)
+	}
+	s.data[path][key] = v
+}
+
+func (s *Server) AddAccountTransfers(address string, transfers ...go3s.Transfer) {
+	s.Add("/account/transfer", address, transfers)
+}
+
+func (s *Server) AddAccountTransactions(address string, txs ...go3s.Transaction) {
+	s.Add("/account/transactions", address, txs)
+}
+
+func (s *Server) AddAccountDefiActivities(address string, activities ...go3s.DefiActivity) {
+	s.Add("/account/defi/activities", address, activities)
+}
+
+func (s *Server) AddAccountBalanceChanges(address string, changes ...go3s.AccountChangeActivity) {
+	s.Add("/account/balance_change", address, changes)
+}
+
+func (s *Server) AddTokenTransfers(token string, transfers ...go3s.Transfer) {
+	s.Add("/token/transfer", token, transfers)
+}
+
+func (s *Server) AddTokenHolders(token string, holders ...go3s.TokenHolder) {
+	s.Add("/token/holders", token, holders)
+}
+
+func (s *Server) AddBlockTransactions(block int64, txs ...go3s.Transaction) {
+	s.Add("/block/transactions", strconv.FormatInt(block, 10), txs)
+}
+
+func (s *Server) SetAccountDetail(address string, detail go3s.AccountDetail) {
+	s.Seed("/account/detail", address, detail)
+}
+
+func (s *Server) SetTokenMeta(address string, meta go3s.TokenMeta) {
+	s.Seed("/token/meta", address, meta)
+}
+
+func (s *Server) SetTxDetail(tx string, detail go3s.TransactionDetail) {
+	s.Seed("/transaction/detail", tx, detail)
+}
+
+func (s *Server) SetTxActions(tx string, actions go3s.TransactionAction) {
+	s.Seed("/transaction/actions", tx, actions)
+}
+
+func (s *Server) SetBlockDetail(block int64, detail go3s.BlockDetail) {
+	s.Seed("/block/detail", strconv.FormatInt(block, 10), detail)
+}
+
+// Inject adds a fault. Faults are matched in the order they were added.
+func (s *Server) Inject(f Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &f)
+}
+
+// ClearFaults removes every fault.
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// Requests returns the number of requests received for path, faulted ones included.
+func (s *Server) Requests(path string) int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.requests[path]
+}
+
+func (s *Server) handle(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, PRO_PATH_PREFIX)
+	s.mu.Lock()
+	s.requests[path]++
+	fault := s.fault(path)
+	s.mu.Unlock()
+
+	if fault != nil {
+		select {
+		case <-time.After(fault.Latency):
+		case <-r.Context().Done():
+			return
+		}
+		if fault.Status != 0 {
+			if fault.RetryAfter > 0 {
+				w.Header().Set("Retry-After", strconv.Itoa(int(fault.RetryAfter.Seconds())))
+			}
+			writeError(w, fault.Status, "injected fault")
+			return
+		}
+	}
+
+	ep, ok := endpoints[path]
+	if !ok {
+		writeError(w, http.StatusNotFound, "unknown endpoint "+path)
+		return
+	}
+	q := r.URL.Query()
+	key := strings.Join(q[ep.key], ",")
+	s.mu.Lock()
+	data, ok := s.data[path][key]
+	s.mu.Unlock()
+
+	switch ep.shape {
+	case shapeRaw:
+		if !ok {
+			writeError(w, http.StatusNotFound, "no data for "+key)
+			return
+		}
+		w.Header().Set("Content-Type", "text/csv")
+		w.Write(data.([]byte))
+	case shapeObject:
+		if !ok {
+			writeError(w, http.StatusNotFound, "no data for "+key)
+			return
+		}
+		writeData(w, data)
+	default:
+		items, _ := data.([]map[string]any)
+		items, total, err := query(ep, items, q)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err.Error())
+			return
+		}
+		switch ep.shape {
+		case shapeItems:
+			writeData(w, map[string]any{"items": items, "total": total})
+		case shapeData:
+			writeData(w, map[string]any{"data": items, "total": total})
+		case shapeTransactions:
+			writeData(w, map[string]any{"transactions": items, "total": total})
+		default:
+			writeData(w, items)
+		}
+	}
+}
+
+// fault returns the fault matching path and counts it, s.mu must be held.
+func (s *Server) fault(path string) *Fault {
+	for i, f := range s.faults {
+		if f.Path != "" && f.Path != path {
+			continue
+		}
+		if f.Times > 0 {
+			f.Times--
+			if f.Times == 0 {
+				s.faults = slices.Delete(s.faults, i, i+1)
+			}
+		}
+		return f
+	}
+	return nil
+}
+
+// query filters, sorts and pages items as requested by q.
+// It returns the page and the number of items before paging.
+func query(ep endpoint, items []map[string]any, q map[string][]string) ([]map[string]any, int, error) {
+	items = slices.Clone(items)
+	if r := q["block_time[]"]; len(r) == 2 {
+		from, err1 := strconv.ParseFloat(r[0], 64)
+		to, err2 := strconv.ParseFloat(r[1], 64)
+		if err1 != nil || err2 != nil {
+			return nil, 0, fmt.Errorf("invalid block_time %v", r)
+		}
+		items = slices.DeleteFunc(items, func(it map[string]any) bool {
+			t, _ := it["block_time"].(float64)
+			return t < from || t > to
+		})
+	}
+	if sortBy := first(q, "sort_by"); sortBy != "" {
+		desc := first(q, "sort_order") != "asc"
+		slices.SortStableFunc(items, func(a, b map[string]any) int {
+			c := compare(a[sortBy], b[sortBy])
+			if desc {
+				return -c
+			}
+			return c
+		})
+	}
+	if before := first(q, "before"); before != "" && ep.cursor != "" {
+		i := slices.IndexFunc(items, func(it map[string]any) bool {
+			return it[ep.cursor] == before
+		})
+		if i < 0 {
+			return nil, 0, fmt.Errorf("unknown before %s", before)
+		}
+		items = items[i+1:]
+	}
+	total := len(items)
+	if ep.limit != "" {
+		if limit, err := strconv.Atoi(first(q, ep.limit)); err == nil && limit < len(items) {
+			items = items[:limit]
+		}
+		return items, total, nil
+	}
+	page, err := strconv.Atoi(cmp.Or(first(q, "page"), "1"))
+	if err != nil || page < 1 {
+		return nil, 0, fmt.Errorf("invalid page %s", first(q, "page"))
+	}
+	pageSize, err := strconv.Atoi(cmp.Or(first(q, "page_size"), "10"))
+	if err != nil || pageSize < 1 {
+		return nil, 0, fmt.Errorf("invalid page_size %s", first(q, "page_size"))
+	}
+	start := min((page-1)*pageSize, len(items))
+	end := min(start+pageSize, len(items))
+	return items[start:end], total, nil
+}
+
+func first(q map[string][]string, key string) string {
+	if v := q[key]; len(v) > 0 {
+		return v[0]
+	}
+	return ""
+}
+
+// compare orders json values, numbers before strings.
+func compare(a, b any) int {
+	af, aNum := a.(float64)
+	bf, bNum := b.(float64)
+	switch {
+	case aNum && bNum:
+		return cmp.Compare(af, bf)
+	case aNum:
+		return -1
+	case bNum:
+		return 1
+	}
+	as, _ := a.(string)
+	bs, _ := b.(string)
+	return strings.Compare(as, bs)
+}
+
+func writeData(w http.ResponseWriter, data any) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]any{"success": true, "data": data})
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(map[string]any{
+		"success": false,
+		"errors":  map[string]any{"code": status, "message": message},
+	})
+}
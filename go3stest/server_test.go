@@ -0,0 +1,133 @@
+package go3stest
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/dwdwow/go3s"
+)
+
+func TestServerPaging(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	var transfers []go3s.Transfer
+	for i := 0; i < 95; i++ {
+		transfers = append(transfers, go3s.Transfer{TransID: strconv.Itoa(i), BlockTime: int64(i)})
+	}
+	srv.AddAccountTransfers("addr", transfers...)
+	client := srv.Client()
+
+	got, err := client.AccountTransfersPagingQuery(context.Background(), 1, 0, 3, "addr", &go3s.AccountTransfersParams{PageSize: go3s.LargePageSize10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 95 || got[0].BlockTime != 94 || got[94].BlockTime != 0 {
+		t.Fatalf("transfers = %d, want 95 newest first", len(got))
+	}
+
+	got, err = client.AccountTransfers(context.Background(), "addr", &go3s.AccountTransfersParams{
+		BlockTimeRange: []int64{10, 19},
+		SortOrder:      go3s.SortOrderAsc,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 10 || got[0].BlockTime != 10 {
+		t.Fatalf("transfers = %v, want block times 10 to 19 ascending", got)
+	}
+}
+
+func TestServerCursor(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	var txs []go3s.Transaction
+	for i := 0; i < 35; i++ {
+		txs = append(txs, go3s.Transaction{TxHash: "tx" + strconv.Itoa(i), BlockTime: int64(100 - i)})
+	}
+	srv.AddAccountTransactions("addr", txs...)
+	got, err := srv.Client().AccountTransactionsPagingQuery(context.Background(), 0, "addr", &go3s.AccountTransactionsParams{Limit: go3s.SmallPageSize10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 35 || got[34].TxHash != "tx34" {
+		t.Fatalf("transactions = %d, want 35", len(got))
+	}
+	if n := srv.Requests("/account/transactions"); n != 4 {
+		t.Fatalf("requests = %d, want 4", n)
+	}
+}
+
+func TestServerShapes(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddTokenHolders("token", go3s.TokenHolder{Rank: 1}, go3s.TokenHolder{Rank: 2})
+	srv.SetTokenMeta("token", go3s.TokenMeta{Symbol: "TKN"})
+	client := srv.Client()
+
+	holders, err := client.TokenHolders(context.Background(), "token", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if holders.Total != 2 || len(holders.Items) != 2 {
+		t.Fatalf("holders = %+v", holders)
+	}
+	meta, err := client.TokenMeta(context.Background(), "token")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if meta.Symbol != "TKN" {
+		t.Fatalf("meta = %+v", meta)
+	}
+	if _, err := client.TokenMeta(context.Background(), "unknown"); !errors.Is(err, go3s.Err404) {
+		t.Fatalf("err = %v, want Err404", err)
+	}
+}
+
+func TestServerFaults(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddAccountTransfers("addr", go3s.Transfer{TransID: "1"})
+	client := srv.Client()
+
+	srv.Inject(Fault{Path: "/account/transfer", Status: 429, Times: 1})
+	if _, err := client.AccountTransfers(context.Background(), "addr", nil); !errors.Is(err, go3s.Err429) {
+		t.Fatalf("err = %v, want Err429", err)
+	}
+	if _, err := client.AccountTransfers(context.Background(), "addr", nil); err != nil {
+		t.Fatalf("err = %v after the fault was used up", err)
+	}
+
+	srv.Inject(Fault{Status: 500, Times: 2})
+	got, err := srv.Client(go3s.WithRetryPolicy(&go3s.GetterOption{RetryInterval: time.Millisecond, MaxRetries: 3})).AccountTransfers(context.Background(), "addr", nil)
+	if err != nil || len(got) != 1 {
+		t.Fatalf("transfers = %v, err = %v, want a success after 2 retries", got, err)
+	}
+
+	srv.Inject(Fault{Latency: time.Second})
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	if _, err := client.AccountTransfers(ctx, "addr", nil); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("err = %v, want DeadlineExceeded", err)
+	}
+}
+
+func TestServerAdd(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	srv.AddAccountTransfers("addr", go3s.Transfer{TransID: "1", BlockTime: 1})
+	srv.AddAccountTransfers("addr", go3s.Transfer{TransID: "2", BlockTime: 2})
+	got, err := srv.Client().AccountTransfers(context.Background(), "addr", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 || got[0].TransID != "2" {
+		t.Fatalf("transfers = %v, want both newest first", got)
+	}
+	srv.Seed("/account/transfer", "addr", []go3s.Transfer{{TransID: "3"}})
+	if got, _ = srv.Client().AccountTransfers(context.Background(), "addr", nil); len(got) != 1 {
+		t.Fatalf("transfers = %v, want Seed to replace them", got)
+	}
+}
@@ -0,0 +1,169 @@
+// Command mockgen generates MockClient from the interfaces of api.go.
+//
+//	go run ./internal/mockgen -out mock_api.go
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/format"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"log"
+	"os"
+	"strings"
+)
+
+type param struct {
+	name string
+	typ  string
+}
+
+type method struct {
+	name    string
+	params  []param
+	results []string
+}
+
+func main() {
+	in := flag.String("in", "api.go", "file declaring the interfaces")
+	out := flag.String("out", "mock_api.go", "generated file")
+	typeName := flag.String("type", "MockClient", "name of the mock type")
+	flag.Parse()
+
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, *in, nil, 0)
+	if err != nil {
+		log.Fatal(err)
+	}
+	methods := collect(fset, f)
+	src, err := format.Source(generate(f.Name.Name, *typeName, *in, methods))
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := os.WriteFile(*out, src, 0o644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// collect returns the methods of every interface in f, in declaration order.
+func collect(fset *token.FileSet, f *ast.File) []method {
+	var methods []method
+	ast.Inspect(f, func(n ast.Node) bool {
+		it, ok := n.(*ast.InterfaceType)
+		if !ok {
+			return true
+		}
+		for _, field := range it.Methods.List {
+			ft, ok := field.Type.(*ast.FuncType)
+			if !ok || len(field.Names) == 0 {
+				continue // embedded interface
+			}
+			m := method{name: field.Names[0].Name}
+			for _, p := range ft.Params.List {
+				typ := expr(fset, p.Type)
+				if len(p.Names) == 0 {
+					m.params = append(m.params, param{fmt.Sprintf("p%d", len(m.params)), typ})
+				}
+				for _, n := range p.Names {
+					m.params = append(m.params, param{n.Name, typ})
+				}
+			}
+			if ft.Results != nil {
+				for _, r := range ft.Results.List {
+					for range max(1, len(r.Names)) {
+						m.results = append(m.results, expr(fset, r.Type))
+					}
+				}
+			}
+			methods = append(methods, m)
+		}
+		return false
+	})
+	return methods
+}
+
+func expr(fset *token.FileSet, e ast.Expr) string {
+	var b bytes.Buffer
+	printer.Fprint(&b, fset, e)
+	return b.String()
+}
+
+func generate(pkg, typeName, in string, methods []method) []byte {
+	var b bytes.Buffer
+	p := func(format string, args ...any) {
+		fmt.Fprintf(&b, format, args...)
+	}
+	p("// Code generated by go run ./internal/mockgen; DO NOT EDIT.\n\n")
+	p("package %s\n\n", pkg)
+	p("import (\n\"context\"\n\"fmt\"\n\"sync\"\n)\n\n")
+	p("var ErrNotMocked = fmt.Errorf(\"solscan: method is not mocked\")\n\n")
+	p("// %s implements the interfaces of %s for tests. Set the func field of a method,\n", typeName, in)
+	p("// e.g. %sFunc, to define what it returns. Methods whose func is not set\n", methods[0].name)
+	p("// return zero values and ErrNotMocked. Every call is recorded, see Calls.\n")
+	p("type %s struct {\n", typeName)
+	for _, m := range methods {
+		p("%sFunc func(%s) %s\n", m.name, m.paramList(), m.resultList())
+	}
+	p("\nmu sync.Mutex\ncalls map[string][][]any\n}\n\n")
+	p("var _ API = (*%s)(nil)\n\n", typeName)
+	p("// Calls returns the arguments of every call of method, in call order.\n")
+	p("func (m *%s) Calls(method string) [][]any {\n", typeName)
+	p("m.mu.Lock()\ndefer m.mu.Unlock()\nreturn m.calls[method]\n}\n\n")
+	p("func (m *%s) record(method string, args ...any) {\n", typeName)
+	p("m.mu.Lock()\ndefer m.mu.Unlock()\nif m.calls == nil {\nm.calls = map[string][][]any{}\n}\n")
+	p("m.calls[method] = append(m.calls[method], args)\n}\n")
+	for _, m := range methods {
+		p("\nfunc (m *%s) %s(%s) %s {\n", typeName, m.name, m.paramList(), m.resultList())
+		p("m.record(%q, %s)\n", m.name, m.argList())
+		p("if m.%sFunc != nil {\nreturn m.%sFunc(%s)\n}\n", m.name, m.name, m.argList())
+		p("%s\n}\n", m.zeroReturn())
+	}
+	return b.Bytes()
+}
+
+func (m method) paramList() string {
+	ps := make([]string, len(m.params))
+	for i, p := range m.params {
+		ps[i] = p.name + " " + p.typ
+	}
+	return strings.Join(ps, ", ")
+}
+
+func (m method) argList() string {
+	as := make([]string, len(m.params))
+	for i, p := range m.params {
+		as[i] = p.name
+	}
+	return strings.Join(as, ", ")
+}
+
+func (m method) resultList() string {
+	if len(m.results) == 1 {
+		return m.results[0]
+	}
+	return "(" + strings.Join(m.results, ", ") + ")"
+}
+
+// zeroReturn returns zero values and ErrNotMocked. An unmocked iterator
+// is not nil but stops at once with ErrNotMocked.
+func (m method) zeroReturn() string {
+	if len(m.results) == 1 && strings.HasPrefix(m.results[0], "*Iterator[") {
+		item := strings.TrimSuffix(strings.TrimPrefix(m.results[0], "*Iterator["), "]")
+		return fmt.Sprintf("return NewIterator(%s, func(context.Context) ([]%s, bool, error) {\nreturn nil, false, ErrNotMocked\n})", m.params[0].name, item)
+	}
+	var b strings.Builder
+	rs := make([]string, len(m.results))
+	for i, r := range m.results {
+		if r == "error" {
+			rs[i] = "ErrNotMocked"
+			continue
+		}
+		fmt.Fprintf(&b, "var r%d %s\n", i, r)
+		rs[i] = fmt.Sprintf("r%d", i)
+	}
+	return b.String() + "return " + strings.Join(rs, ", ")
+}
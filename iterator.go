@@ -0,0 +1,119 @@
+package go3s
+
+import (
+	"context"
+	"strconv"
+)
+
+// Iterator lazily walks a paginated endpoint, fetching one page at a time
+// until the endpoint is exhausted or the caller stops.
+//
+//	it := client.AccountTransfersIter(ctx, address, nil)
+//	for it.Next() {
+//		transfer := it.Item()
+//	}
+//	if err := it.Err(); err != nil {
+//	}
+type Iterator[T any] struct {
+	ctx   context.Context
+	fetch func(ctx context.Context) (items []T, more bool, err error)
+	buf   []T
+	item  T
+	more  bool
+	err   error
+}
+
+// NewIterator creates an Iterator calling fetch for every page.
+// fetch returns the page items and whether more pages may follow.
+func NewIterator[T any](ctx context.Context, fetch func(ctx context.Context) (items []T, more bool, err error)) *Iterator[T] {
+	return &Iterator[T]{
+		ctx:   ctx,
+		fetch: fetch,
+		more:  true,
+	}
+}
+
+// Next advances to the next item, fetching the next page if needed.
+// It returns false when the items are exhausted or an error occurred.
+func (it *Iterator[T]) Next() bool {
+	for len(it.buf) == 0 {
+		if !it.more || it.err != nil {
+			return false
+		}
+		items, more, err := it.fetch(it.ctx)
+		if err != nil {
+			it.err = err
+			return false
+		}
+		it.buf = items
+		it.more = more && len(items) > 0
+	}
+	it.item = it.buf[0]
+	it.buf[0] = *new(T)
+	it.buf = it.buf[1:]
+	return true
+}
+
+// Item returns the current item.
+func (it *Iterator[T]) Item() T {
+	return it.item
+}
+
+// Err returns the error that stopped the iteration, if any.
+func (it *Iterator[T]) Err() error {
+	return it.err
+}
+
+// All returns the remaining items as a function shaped like iter.Seq2[T, error],
+// for range-over-func callers on Go 1.23 and later.
+// The error, if any, is yielded last with a zero item.
+func (it *Iterator[T]) All() func(yield func(T, error) bool) {
+	return func(yield func(T, error) bool) {
+		for it.Next() {
+			if !yield(it.Item(), nil) {
+				return
+			}
+		}
+		if it.err != nil {
+			yield(*new(T), it.err)
+		}
+	}
+}
+
+// newPageIterator walks the pages of sg starting at its "page" param.
+// A page shorter than the "page_size" param is the last one.
+func newPageIterator[D, T any](ctx context.Context, sg SimpleGetter[D], items func(D) []T) *Iterator[T] {
+	page, err := strconv.ParseInt(sg.Params.Get("page"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+	pageSize, _ := strconv.ParseInt(sg.Params.Get("page_size"), 10, 64)
+	return NewIterator(ctx, func(ctx context.Context) ([]T, bool, error) {
+		g := sg
+		g.Params = cloneParams(sg.Params)
+		g.Params.Set("page", strconv.FormatInt(page, 10))
+		d, err := g.Do(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		page++
+		ts := items(d)
+		return ts, int64(len(ts)) >= pageSize, nil
+	})
+}
+
+func sliceItems[T any](d []T) []T {
+	return d
+}
+
+func withTotalItems[T any](d RespDataWithTotal[T]) []T {
+	return d.Items
+}
+
+func withTotalData[T any](d RespDataWithTotal[T]) []T {
+	return d.Data
+}
+
+func withTotalTransactions(d RespDataWithTotal[Transaction]) []Transaction {
+	return d.Transactions
+}
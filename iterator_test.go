@@ -0,0 +1,69 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+)
+
+// newTransfersServer serves total transfers on /account/transfer with page/page_size paging.
+func newTransfersServer(t *testing.T, total int, calls *atomic.Int64) *httptest.Server {
+	t.Helper()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(r.URL.Query().Get("page_size"))
+		data := []Transfer{}
+		for i := (page - 1) * size; i < page*size && i < total; i++ {
+			data = append(data, Transfer{TransID: strconv.Itoa(i), BlockTime: int64(total - i)})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestIterator(t *testing.T) {
+	var calls atomic.Int64
+	srv := newTransfersServer(t, 25, &calls)
+	client := NewClient(WithProBaseURL(srv.URL))
+	it := client.AccountTransfersIter(context.Background(), "addr", &AccountTransfersParams{PageSize: LargePageSize10})
+	n := 0
+	for it.Next() {
+		if it.Item().TransID != strconv.Itoa(n) {
+			t.Fatalf("item %d = %s", n, it.Item().TransID)
+		}
+		n++
+	}
+	if it.Err() != nil {
+		t.Fatal(it.Err())
+	}
+	if n != 25 || calls.Load() != 3 {
+		t.Fatalf("items = %d, calls = %d, want 25 and 3", n, calls.Load())
+	}
+}
+
+func TestIteratorAllStopsEarly(t *testing.T) {
+	var calls atomic.Int64
+	srv := newTransfersServer(t, 1000, &calls)
+	client := NewClient(WithProBaseURL(srv.URL))
+	it := client.AccountTransfersIter(context.Background(), "addr", &AccountTransfersParams{PageSize: LargePageSize10, Page: 2})
+	n := 0
+	it.All()(func(tr Transfer, err error) bool {
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n == 0 && tr.TransID != "10" {
+			t.Fatalf("first item = %s, want 10", tr.TransID)
+		}
+		n++
+		return n < 15
+	})
+	if n != 15 || calls.Load() != 2 {
+		t.Fatalf("items = %d, calls = %d, want 15 and 2", n, calls.Load())
+	}
+}
@@ -0,0 +1,296 @@
+package go3s
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+var ErrCUBudgetExceeded = fmt.Errorf("solscan: compute unit budget exceeded")
+
+// Limiter is waited on before every request. *rate.Limiter satisfies it.
+type Limiter interface {
+	Wait(ctx context.Context) error
+}
+
+// LimiterFeedback is implemented by limiters that adapt to responses.
+// Observe is called after every request with its error, nil on success.
+type LimiterFeedback interface {
+	Observe(err error)
+}
+
+// AdaptiveLimiter is an AIMD limiter: it halves its rate when Solscan answers 429
+// and adds IncreaseRPM back after every successful request, staying within [min, max].
+// Set the exported fields before the limiter is used.
+type AdaptiveLimiter struct {
+	// IncreaseRPM is added to the rate after every success.
+	IncreaseRPM float64
+	// DecreaseFactor multiplies the rate after a 429.
+	DecreaseFactor float64
+	// Cooldown is the minimum time between two decreases,
+	// so a burst of concurrent 429s counts once.
+	Cooldown time.Duration
+
+	limiter *rate.Limiter
+	minRPM  float64
+	maxRPM  float64
+
+	mu           sync.Mutex
+	rpm          float64
+	lastDecrease time.Time
+}
+
+// NewAdaptiveLimiter creates an AdaptiveLimiter starting at maxRPM requests per minute.
+func NewAdaptiveLimiter(minRPM, maxRPM, burst int) *AdaptiveLimiter {
+	if minRPM <= 0 {
+		minRPM = 1
+	}
+	if maxRPM < minRPM {
+		maxRPM = minRPM
+	}
+	return &AdaptiveLimiter{
+		IncreaseRPM:    1,
+		DecreaseFactor: 0.5,
+		Cooldown:       time.Second,
+		limiter:        NewRequestsPerMinuteLimiter(maxRPM, burst),
+		minRPM:         float64(minRPM),
+		maxRPM:         float64(maxRPM),
+		rpm:            float64(maxRPM),
+	}
+}
+
+func (l *AdaptiveLimiter) Wait(ctx context.Context) error {
+	return l.limiter.Wait(ctx)
+}
+
+// Observe lowers the rate on Err429 and raises it on success.
+// Other errors leave the rate unchanged.
+func (l *AdaptiveLimiter) Observe(err error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	rpm := l.rpm
+	switch {
+	case err == nil:
+		rpm += l.IncreaseRPM
+	case errors.Is(err, Err429):
+		now := time.Now()
+		if now.Sub(l.lastDecrease) < l.Cooldown {
+			return
+		}
+		l.lastDecrease = now
+		rpm *= l.DecreaseFactor
+	default:
+		return
+	}
+	rpm = math.Max(l.minRPM, math.Min(l.maxRPM, rpm))
+	if rpm == l.rpm {
+		return
+	}
+	l.rpm = rpm
+	l.limiter.SetLimit(rate.Limit(rpm / 60))
+}
+
+// Rate returns the current effective rate in requests per minute.
+func (l *AdaptiveLimiter) Rate() float64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.rpm
+}
+
+// CUBudget tracks the compute units (CUs) spent by requests.
+// It can cap the total spend and the spend per minute.
+// A CUBudget is safe for concurrent use.
+type CUBudget struct {
+	max       int64
+	perMinute *rate.Limiter
+
+	mu     sync.Mutex
+	used   int64
+	remote int64 // remaining CUs reported by APIUsage, -1 if unknown
+	base   int64 // used when remote was reported
+}
+
+// NewCUBudget creates a CUBudget allowing at most max CUs in total
+// and perMinute CUs per minute. 0 means no limit.
+func NewCUBudget(max, perMinute int64) *CUBudget {
+	b := &CUBudget{
+		max:    max,
+		remote: -1,
+	}
+	if perMinute > 0 {
+		b.perMinute = rate.NewLimiter(rate.Limit(float64(perMinute)/60), int(perMinute))
+	}
+	return b
+}
+
+// Spend reserves cu compute units, waiting for the per minute limit if needed.
+// It returns ErrCUBudgetExceeded if the total cap would be exceeded.
+func (b *CUBudget) Spend(ctx context.Context, cu int64) error {
+	if cu <= 0 {
+		return nil
+	}
+	b.mu.Lock()
+	if b.max > 0 && b.used+cu > b.max {
+		b.mu.Unlock()
+		return ErrCUBudgetExceeded
+	}
+	if b.remote >= 0 && b.used-b.base+cu > b.remote {
+		b.mu.Unlock()
+		return ErrCUBudgetExceeded
+	}
+	b.used += cu
+	b.mu.Unlock()
+	if b.perMinute == nil {
+		return nil
+	}
+	n := int(cu)
+	if n > b.perMinute.Burst() {
+		n = b.perMinute.Burst()
+	}
+	if err := b.perMinute.WaitN(ctx, n); err != nil {
+		b.refund(cu)
+		return err
+	}
+	return nil
+}
+
+func (b *CUBudget) refund(cu int64) {
+	b.mu.Lock()
+	b.used -= cu
+	b.mu.Unlock()
+}
+
+// Used returns the CUs spent so far.
+func (b *CUBudget) Used() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.used
+}
+
+// Remaining returns the CUs left before the budget is exceeded,
+// or -1 if the budget is unlimited.
+func (b *CUBudget) Remaining() int64 {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	remaining := int64(-1)
+	if b.max > 0 {
+		remaining = b.max - b.used
+	}
+	if b.remote >= 0 {
+		r := b.remote - (b.used - b.base)
+		if remaining < 0 || r < remaining {
+			remaining = r
+		}
+	}
+	return remaining
+}
+
+// Reconcile aligns the budget with the account usage reported by Solscan,
+// so spend by other processes sharing the API key is taken into account.
+func (b *CUBudget) Reconcile(usage APIUsage) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.remote = usage.RemainingCUs
+	b.base = b.used
+}
+
+// CUCosts maps endpoint paths, e.g. "/token/meta", to the CUs charged per request.
+// Endpoints missing from the table cost DEFAULT_CU_COST.
+type CUCosts map[string]int64
+
+// DefaultCUCosts lists the CU cost of every endpoint of the client.
+var DefaultCUCosts = CUCosts{
+	"/chaininfo":     0,
+	"/monitor/usage": 0,
+
+	"/account/detail":          DEFAULT_CU_COST,
+	"/account/transfer":        DEFAULT_CU_COST,
+	"/account/defi/activities": DEFAULT_CU_COST,
+	"/account/balance_change":  DEFAULT_CU_COST,
+	"/account/transactions":    DEFAULT_CU_COST,
+	"/account/stake":           DEFAULT_CU_COST,
+	"/account/token-accounts":  DEFAULT_CU_COST,
+	"/account/reward/export":   EXPORT_CU_COST,
+	"/account/transfer/export": EXPORT_CU_COST,
+
+	"/token/transfer":        DEFAULT_CU_COST,
+	"/token/defi/activities": DEFAULT_CU_COST,
+	"/token/markets":         DEFAULT_CU_COST,
+	"/token/list":            DEFAULT_CU_COST,
+	"/token/trending":        DEFAULT_CU_COST,
+	"/token/price":           DEFAULT_CU_COST,
+	"/token/holders":         DEFAULT_CU_COST,
+	"/token/meta":            DEFAULT_CU_COST,
+	"/token/top":             DEFAULT_CU_COST,
+
+	"/nft/news":             DEFAULT_CU_COST,
+	"/nft/activities":       DEFAULT_CU_COST,
+	"/nft/collection/lists": DEFAULT_CU_COST,
+	"/nft/collection/items": DEFAULT_CU_COST,
+
+	"/market/list":   DEFAULT_CU_COST,
+	"/market/info":   DEFAULT_CU_COST,
+	"/market/volume": DEFAULT_CU_COST,
+
+	"/transaction/last":    DEFAULT_CU_COST,
+	"/transaction/detail":  DEFAULT_CU_COST,
+	"/transaction/actions": DEFAULT_CU_COST,
+
+	"/block/last":         DEFAULT_CU_COST,
+	"/block/transactions": DEFAULT_CU_COST,
+	"/block/detail":       DEFAULT_CU_COST,
+}
+
+// cost returns the CUs charged by a request to path.
+func (c CUCosts) cost(path string) int64 {
+	if c == nil {
+		c = DefaultCUCosts
+	}
+	cu, ok := c["/"+strings.Trim(path, "/")]
+	if !ok {
+		return DEFAULT_CU_COST
+	}
+	return cu
+}
+
+type cuBudgetKey struct{}
+
+// ContextWithCUBudget returns a context whose requests also spend from b,
+// e.g. to cap the spend of a single job on top of the client budget.
+// A request coalesced with an identical one in flight is sent once,
+// and only charged to the context of the caller sending it.
+func ContextWithCUBudget(ctx context.Context, b *CUBudget) context.Context {
+	return context.WithValue(ctx, cuBudgetKey{}, b)
+}
+
+func cuBudgetFromContext(ctx context.Context) *CUBudget {
+	b, _ := ctx.Value(cuBudgetKey{}).(*CUBudget)
+	return b
+}
+
+// spendCU spends cu from the option budget and the context budget.
+func spendCU(ctx context.Context, option *GetterOption, cu int64) error {
+	if cu <= 0 {
+		return nil
+	}
+	if option != nil && option.CUBudget != nil {
+		if err := option.CUBudget.Spend(ctx, cu); err != nil {
+			return err
+		}
+	}
+	if b := cuBudgetFromContext(ctx); b != nil {
+		if err := b.Spend(ctx, cu); err != nil {
+			if option != nil && option.CUBudget != nil {
+				option.CUBudget.refund(cu)
+			}
+			return err
+		}
+	}
+	return nil
+}
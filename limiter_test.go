@@ -0,0 +1,176 @@
+package go3s
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+func TestCUBudget(t *testing.T) {
+	b := NewCUBudget(250, 0)
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		if err := b.Spend(ctx, 100); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := b.Spend(ctx, 100); !errors.Is(err, ErrCUBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrCUBudgetExceeded", err)
+	}
+	if b.Used() != 200 || b.Remaining() != 50 {
+		t.Fatalf("used = %d, remaining = %d", b.Used(), b.Remaining())
+	}
+	b.Reconcile(APIUsage{RemainingCUs: 30})
+	if b.Remaining() != 30 {
+		t.Fatalf("remaining after reconcile = %d, want 30", b.Remaining())
+	}
+}
+
+func TestClientCUBudget(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"))
+	ctx := context.Background()
+	if _, err := client.TokenMeta(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if used := client.CUBudget().Used(); used != DEFAULT_CU_COST {
+		t.Fatalf("client used = %d, want %d", used, DEFAULT_CU_COST)
+	}
+
+	job := NewCUBudget(DEFAULT_CU_COST, 0)
+	jobCtx := ContextWithCUBudget(ctx, job)
+	if _, err := client.TokenMeta(jobCtx, "b"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.TokenMeta(jobCtx, "c"); !errors.Is(err, ErrCUBudgetExceeded) {
+		t.Fatalf("err = %v, want ErrCUBudgetExceeded", err)
+	}
+	if calls.Load() != 2 {
+		t.Fatalf("calls = %d, want 2", calls.Load())
+	}
+	if used := client.CUBudget().Used(); used != 2*DEFAULT_CU_COST {
+		t.Fatalf("client used = %d, want refund of the rejected request", used)
+	}
+}
+
+func TestClientCUCosts(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, "/export") {
+			w.Write([]byte("block_time\n"))
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+	ctx := context.Background()
+	cases := []struct {
+		call func(c *Client) error
+		want int64
+	}{
+		{func(c *Client) error { _, err := c.AccountTransfersExport(ctx, "a", nil); return err }, EXPORT_CU_COST},
+		{func(c *Client) error { _, err := c.AccountRewardsExport(ctx, "a", 0, 1); return err }, EXPORT_CU_COST},
+		{func(c *Client) error { _, err := c.APIUsage(ctx); return err }, 0},
+		{func(c *Client) error { _, err := c.ChainInfo(ctx); return err }, 0},
+		{func(c *Client) error { _, err := c.TokenMeta(ctx, "a"); return err }, DEFAULT_CU_COST},
+	}
+	for i, tc := range cases {
+		client := NewClient(WithProBaseURL(srv.URL), WithPublicBaseURL(srv.URL), WithToken("t"))
+		if err := tc.call(client); err != nil {
+			t.Fatalf("case %d: %v", i, err)
+		}
+		if used := client.CUBudget().Used(); used != tc.want {
+			t.Fatalf("case %d: used = %d, want %d", i, used, tc.want)
+		}
+	}
+
+	client := NewClient(WithProBaseURL(srv.URL), WithToken("t"), WithCUCosts(CUCosts{"/token/meta": 7}))
+	if _, err := client.TokenMeta(ctx, "a"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := client.AccountTransfersExport(ctx, "a", nil); err != nil {
+		t.Fatal(err)
+	}
+	if used := client.CUBudget().Used(); used != 7+DEFAULT_CU_COST {
+		t.Fatalf("used = %d, want the overridden cost and DEFAULT_CU_COST for missing paths", used)
+	}
+}
+
+func TestPerClientLimiter(t *testing.T) {
+	l := NewRequestsPerMinuteLimiter(600, 5)
+	if l.Limit() != 10 || l.Burst() != 5 {
+		t.Fatalf("limit = %v, burst = %d, want 10/s and 5", l.Limit(), l.Burst())
+	}
+	a, b := NewV2Client("a"), NewV2Client("b")
+	if a.limiter == b.limiter {
+		t.Fatal("clients share a limiter by default")
+	}
+	if l := a.limiter.(*rate.Limiter); l.Limit() != rate.Limit(float64(V2_MAX_REQUESTS_PER_MINUTE)/60) {
+		t.Fatalf("v2 limit = %v", l.Limit())
+	}
+	c, d := NewClient(WithLimiter(V3Limiter)), NewClient(WithLimiter(V3Limiter))
+	if c.limiter != d.limiter {
+		t.Fatal("WithLimiter did not share the limiter")
+	}
+}
+
+func TestAdaptiveLimiter(t *testing.T) {
+	l := NewAdaptiveLimiter(100, 1000, 1)
+	l.Cooldown = 0
+	l.Observe(Err429)
+	if l.Rate() != 500 {
+		t.Fatalf("rate after 429 = %v, want 500", l.Rate())
+	}
+	l.Observe(&APIError{StatusCode: http.StatusTooManyRequests, Err: Err429})
+	l.Observe(Err429)
+	l.Observe(Err429)
+	if l.Rate() != 100 {
+		t.Fatalf("rate = %v, want floor 100", l.Rate())
+	}
+	l.Observe(Err404)
+	l.Observe(nil)
+	if l.Rate() != 101 {
+		t.Fatalf("rate after success = %v, want 101", l.Rate())
+	}
+	l.Cooldown = time.Hour
+	l.lastDecrease = time.Time{}
+	l.Observe(Err429)
+	l.Observe(Err429)
+	if l.Rate() != 100 {
+		t.Fatalf("rate = %v, want one decrease within cooldown", l.Rate())
+	}
+}
+
+func TestClientAdaptiveLimiter(t *testing.T) {
+	var calls atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if calls.Add(1) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"success":true,"data":{}}`))
+	}))
+	defer srv.Close()
+	client := NewClient(
+		WithProBaseURL(srv.URL),
+		WithAdaptiveLimiter(60, 6000, 10),
+		WithRetryPolicy(&GetterOption{RetryInterval: time.Millisecond, MaxRetries: 2}),
+	)
+	if _, err := client.TokenMeta(context.Background(), "a"); err != nil {
+		t.Fatal(err)
+	}
+	if r := client.Limiter().(*AdaptiveLimiter).Rate(); r != 3001 {
+		t.Fatalf("rate = %v, want 3001", r)
+	}
+}
@@ -0,0 +1,820 @@
+// Code generated by go run ./internal/mockgen; DO NOT EDIT.
+
+package go3s
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+var ErrNotMocked = fmt.Errorf("solscan: method is not mocked")
+
+// MockClient implements the interfaces of api.go for tests. Set the func field of a method,
+// e.g. AccountTransfersFunc, to define what it returns. Methods whose func is not set
+// return zero values and ErrNotMocked. Every call is recorded, see Calls.
+type MockClient struct {
+	AccountTransfersFunc                 func(ctx context.Context, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersPagingQueryFunc      func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersIterFunc             func(ctx context.Context, address string, optParams *AccountTransfersParams) *Iterator[Transfer]
+	AccountTransfersShardQueryFunc       func(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error)
+	AccountTransfersStableQueryFunc      func(ctx context.Context, totalSize int64, address string, optParams *AccountTransfersParams) ([]Transfer, []BlockTimeGap, error)
+	AccountTokenAccountsFunc             func(ctx context.Context, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error)
+	AccountTokenAccountsPagingQueryFunc  func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error)
+	AccountTokenAccountsIterFunc         func(ctx context.Context, address string, optParams *AccountTokenAccountsParams) *Iterator[TokenAccount]
+	AccountDefiActivitiesFunc            func(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountDefiActivitiesPagingQueryFunc func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountDefiActivitiesIterFunc        func(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) *Iterator[DefiActivity]
+	AccountDefiActivitiesShardQueryFunc  func(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error)
+	AccountBalanceChangesFunc            func(ctx context.Context, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountBalanceChangesPagingQueryFunc func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountBalanceChangesIterFunc        func(ctx context.Context, address string, optParams *AccountBalanceChangesParams) *Iterator[AccountChangeActivity]
+	AccountBalanceChangesShardQueryFunc  func(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error)
+	AccountTransactionsFunc              func(ctx context.Context, address string, optParams *AccountTransactionsParams) ([]Transaction, error)
+	AccountTransactionsPagingQueryFunc   func(ctx context.Context, totalSize int64, address string, optParams *AccountTransactionsParams) ([]Transaction, error)
+	AccountTransactionsIterFunc          func(ctx context.Context, address string, optParams *AccountTransactionsParams) *Iterator[Transaction]
+	AccountStakesFunc                    func(ctx context.Context, address string, optParams *AccountStakesParams) ([]AccountStake, error)
+	AccountStakesPagingQueryFunc         func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountStakesParams) ([]AccountStake, error)
+	AccountStakesIterFunc                func(ctx context.Context, address string, optParams *AccountStakesParams) *Iterator[AccountStake]
+	AccountDetailFunc                    func(ctx context.Context, address string) (AccountDetail, error)
+	BatchAccountDetailFunc               func(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]AccountDetail, map[string]error, error)
+	AccountRewardsExportFunc             func(ctx context.Context, address string, timeFrom int64, timeTo int64) ([]byte, error)
+	AccountTransfersExportFunc           func(ctx context.Context, address string, optParams *AccountTransfersExportParams) ([]byte, error)
+	TokenTransfersFunc                   func(ctx context.Context, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenTransfersPagingQueryFunc        func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenTransfersIterFunc               func(ctx context.Context, address string, optParams *TokenTransfersParams) *Iterator[Transfer]
+	TokenTransfersShardQueryFunc         func(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error)
+	TokenDefiActivitiesFunc              func(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error)
+	TokenDefiActivitiesPagingQueryFunc   func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error)
+	TokenDefiActivitiesIterFunc          func(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) *Iterator[DefiActivity]
+	TokenMarketsFunc                     func(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) ([]Market, error)
+	TokenMarketsPagingQueryFunc          func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, token_pair []string, optParams *TokenMarketsParams) ([]Market, error)
+	TokenMarketsIterFunc                 func(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) *Iterator[Market]
+	TokenListFunc                        func(ctx context.Context, optParams *TokenListParams) ([]Token, error)
+	TokenListPagingQueryFunc             func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *TokenListParams) ([]Token, error)
+	TokenListIterFunc                    func(ctx context.Context, optParams *TokenListParams) *Iterator[Token]
+	TokenTrendingFunc                    func(ctx context.Context, limit int64) ([]Token, error)
+	TokenPriceFunc                       func(ctx context.Context, address string, startTime string, endTime string) ([]TokenPrice, error)
+	TokenHoldersFunc                     func(ctx context.Context, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error)
+	TokenHoldersPagingQueryFunc          func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error)
+	TokenHoldersIterFunc                 func(ctx context.Context, address string, optParams *TokenHoldersParams) *Iterator[TokenHolder]
+	TokenMetaFunc                        func(ctx context.Context, address string) (TokenMeta, error)
+	BatchTokenMetaFunc                   func(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]TokenMeta, map[string]error, error)
+	TokenTopFunc                         func(ctx context.Context) ([]TokenTop, error)
+	NFTNewsFunc                          func(ctx context.Context, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error)
+	NFTNewsPagingQueryFunc               func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error)
+	NFTNewsIterFunc                      func(ctx context.Context, optParams *NFTNewsParams) *Iterator[NFTInfo]
+	NFTActivitiesFunc                    func(ctx context.Context, optParams *NFTActivitiesParams) ([]NFTActivity, error)
+	NFTActivitiesPagingQueryFunc         func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTActivitiesParams) ([]NFTActivity, error)
+	NFTActivitiesIterFunc                func(ctx context.Context, optParams *NFTActivitiesParams) *Iterator[NFTActivity]
+	NFTCollectionListFunc                func(ctx context.Context, optParams *NFTCollectionListParams) ([]NFTCollection, error)
+	NFTCollectionListPagingQueryFunc     func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTCollectionListParams) ([]NFTCollection, error)
+	NFTCollectionListIterFunc            func(ctx context.Context, optParams *NFTCollectionListParams) *Iterator[NFTCollection]
+	NFTCollectionItemsFunc               func(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error)
+	NFTCollectionItemsPagingQueryFunc    func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error)
+	NFTCollectionItemsIterFunc           func(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) *Iterator[NFTCollectionItem]
+	TxLastFunc                           func(ctx context.Context, optParams *TxLastParams) ([]Transaction, error)
+	TxDetailFunc                         func(ctx context.Context, tx string) (TransactionDetail, error)
+	BatchTxDetailFunc                    func(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionDetail, map[string]error, error)
+	TxActionsFunc                        func(ctx context.Context, tx string) (TransactionAction, error)
+	BatchTxActionsFunc                   func(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionAction, map[string]error, error)
+	ChainInfoFunc                        func(ctx context.Context) (ChainInfo, error)
+	BlocksLastFunc                       func(ctx context.Context, limit LargePageSize) ([]BlockDetail, error)
+	BlockTransactionsFunc                func(ctx context.Context, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error)
+	BlockTransactionsPagingQueryFunc     func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error)
+	BlockTransactionsIterFunc            func(ctx context.Context, block int64, optParams *BlockTransactionsParams) *Iterator[Transaction]
+	BlockDetailFunc                      func(ctx context.Context, block int64) (BlockDetail, error)
+	PoolMarketListFunc                   func(ctx context.Context, optParams *PoolMarketListParams) ([]PoolMarket, error)
+	PoolMarketListPagingQueryFunc        func(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *PoolMarketListParams) ([]PoolMarket, error)
+	PoolMarketListIterFunc               func(ctx context.Context, optParams *PoolMarketListParams) *Iterator[PoolMarket]
+	PoolMarketInfoFunc                   func(ctx context.Context, address string) (PoolMarketInfo, error)
+	PoolMarketVolumeFunc                 func(ctx context.Context, address string, startTime string, endTime string) (PoolMarketVolume, error)
+	APIUsageFunc                         func(ctx context.Context) (APIUsage, error)
+
+	mu    sync.Mutex
+	calls map[string][][]any
+}
+
+var _ API = (*MockClient)(nil)
+
+// Calls returns the arguments of every call of method, in call order.
+func (m *MockClient) Calls(method string) [][]any {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.calls[method]
+}
+
+func (m *MockClient) record(method string, args ...any) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if m.calls == nil {
+		m.calls = map[string][][]any{}
+	}
+	m.calls[method] = append(m.calls[method], args)
+}
+
+func (m *MockClient) AccountTransfers(ctx context.Context, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
+	m.record("AccountTransfers", ctx, address, optParams)
+	if m.AccountTransfersFunc != nil {
+		return m.AccountTransfersFunc(ctx, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransfersPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
+	m.record("AccountTransfersPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.AccountTransfersPagingQueryFunc != nil {
+		return m.AccountTransfersPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransfersIter(ctx context.Context, address string, optParams *AccountTransfersParams) *Iterator[Transfer] {
+	m.record("AccountTransfersIter", ctx, address, optParams)
+	if m.AccountTransfersIterFunc != nil {
+		return m.AccountTransfersIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Transfer, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountTransfersShardQuery(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountTransfersParams) ([]Transfer, error) {
+	m.record("AccountTransfersShardQuery", ctx, shards, maxConcurrency, address, optParams)
+	if m.AccountTransfersShardQueryFunc != nil {
+		return m.AccountTransfersShardQueryFunc(ctx, shards, maxConcurrency, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransfersStableQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransfersParams) ([]Transfer, []BlockTimeGap, error) {
+	m.record("AccountTransfersStableQuery", ctx, totalSize, address, optParams)
+	if m.AccountTransfersStableQueryFunc != nil {
+		return m.AccountTransfersStableQueryFunc(ctx, totalSize, address, optParams)
+	}
+	var r0 []Transfer
+	var r1 []BlockTimeGap
+	return r0, r1, ErrNotMocked
+}
+
+func (m *MockClient) AccountTokenAccounts(ctx context.Context, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error) {
+	m.record("AccountTokenAccounts", ctx, address, optParams)
+	if m.AccountTokenAccountsFunc != nil {
+		return m.AccountTokenAccountsFunc(ctx, address, optParams)
+	}
+	var r0 []TokenAccount
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTokenAccountsPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountTokenAccountsParams) ([]TokenAccount, error) {
+	m.record("AccountTokenAccountsPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.AccountTokenAccountsPagingQueryFunc != nil {
+		return m.AccountTokenAccountsPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []TokenAccount
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTokenAccountsIter(ctx context.Context, address string, optParams *AccountTokenAccountsParams) *Iterator[TokenAccount] {
+	m.record("AccountTokenAccountsIter", ctx, address, optParams)
+	if m.AccountTokenAccountsIterFunc != nil {
+		return m.AccountTokenAccountsIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]TokenAccount, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountDefiActivities(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
+	m.record("AccountDefiActivities", ctx, address, optParams)
+	if m.AccountDefiActivitiesFunc != nil {
+		return m.AccountDefiActivitiesFunc(ctx, address, optParams)
+	}
+	var r0 []DefiActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountDefiActivitiesPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
+	m.record("AccountDefiActivitiesPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.AccountDefiActivitiesPagingQueryFunc != nil {
+		return m.AccountDefiActivitiesPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []DefiActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountDefiActivitiesIter(ctx context.Context, address string, optParams *AccountDefiActivitiesParams) *Iterator[DefiActivity] {
+	m.record("AccountDefiActivitiesIter", ctx, address, optParams)
+	if m.AccountDefiActivitiesIterFunc != nil {
+		return m.AccountDefiActivitiesIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]DefiActivity, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountDefiActivitiesShardQuery(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountDefiActivitiesParams) ([]DefiActivity, error) {
+	m.record("AccountDefiActivitiesShardQuery", ctx, shards, maxConcurrency, address, optParams)
+	if m.AccountDefiActivitiesShardQueryFunc != nil {
+		return m.AccountDefiActivitiesShardQueryFunc(ctx, shards, maxConcurrency, address, optParams)
+	}
+	var r0 []DefiActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountBalanceChanges(ctx context.Context, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
+	m.record("AccountBalanceChanges", ctx, address, optParams)
+	if m.AccountBalanceChangesFunc != nil {
+		return m.AccountBalanceChangesFunc(ctx, address, optParams)
+	}
+	var r0 []AccountChangeActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountBalanceChangesPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
+	m.record("AccountBalanceChangesPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.AccountBalanceChangesPagingQueryFunc != nil {
+		return m.AccountBalanceChangesPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []AccountChangeActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountBalanceChangesIter(ctx context.Context, address string, optParams *AccountBalanceChangesParams) *Iterator[AccountChangeActivity] {
+	m.record("AccountBalanceChangesIter", ctx, address, optParams)
+	if m.AccountBalanceChangesIterFunc != nil {
+		return m.AccountBalanceChangesIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]AccountChangeActivity, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountBalanceChangesShardQuery(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *AccountBalanceChangesParams) ([]AccountChangeActivity, error) {
+	m.record("AccountBalanceChangesShardQuery", ctx, shards, maxConcurrency, address, optParams)
+	if m.AccountBalanceChangesShardQueryFunc != nil {
+		return m.AccountBalanceChangesShardQueryFunc(ctx, shards, maxConcurrency, address, optParams)
+	}
+	var r0 []AccountChangeActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransactions(ctx context.Context, address string, optParams *AccountTransactionsParams) ([]Transaction, error) {
+	m.record("AccountTransactions", ctx, address, optParams)
+	if m.AccountTransactionsFunc != nil {
+		return m.AccountTransactionsFunc(ctx, address, optParams)
+	}
+	var r0 []Transaction
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransactionsPagingQuery(ctx context.Context, totalSize int64, address string, optParams *AccountTransactionsParams) ([]Transaction, error) {
+	m.record("AccountTransactionsPagingQuery", ctx, totalSize, address, optParams)
+	if m.AccountTransactionsPagingQueryFunc != nil {
+		return m.AccountTransactionsPagingQueryFunc(ctx, totalSize, address, optParams)
+	}
+	var r0 []Transaction
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransactionsIter(ctx context.Context, address string, optParams *AccountTransactionsParams) *Iterator[Transaction] {
+	m.record("AccountTransactionsIter", ctx, address, optParams)
+	if m.AccountTransactionsIterFunc != nil {
+		return m.AccountTransactionsIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Transaction, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountStakes(ctx context.Context, address string, optParams *AccountStakesParams) ([]AccountStake, error) {
+	m.record("AccountStakes", ctx, address, optParams)
+	if m.AccountStakesFunc != nil {
+		return m.AccountStakesFunc(ctx, address, optParams)
+	}
+	var r0 []AccountStake
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountStakesPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *AccountStakesParams) ([]AccountStake, error) {
+	m.record("AccountStakesPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.AccountStakesPagingQueryFunc != nil {
+		return m.AccountStakesPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []AccountStake
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountStakesIter(ctx context.Context, address string, optParams *AccountStakesParams) *Iterator[AccountStake] {
+	m.record("AccountStakesIter", ctx, address, optParams)
+	if m.AccountStakesIterFunc != nil {
+		return m.AccountStakesIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]AccountStake, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) AccountDetail(ctx context.Context, address string) (AccountDetail, error) {
+	m.record("AccountDetail", ctx, address)
+	if m.AccountDetailFunc != nil {
+		return m.AccountDetailFunc(ctx, address)
+	}
+	var r0 AccountDetail
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BatchAccountDetail(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]AccountDetail, map[string]error, error) {
+	m.record("BatchAccountDetail", ctx, addresses, maxConcurrency)
+	if m.BatchAccountDetailFunc != nil {
+		return m.BatchAccountDetailFunc(ctx, addresses, maxConcurrency)
+	}
+	var r0 map[string]AccountDetail
+	var r1 map[string]error
+	return r0, r1, ErrNotMocked
+}
+
+func (m *MockClient) AccountRewardsExport(ctx context.Context, address string, timeFrom int64, timeTo int64) ([]byte, error) {
+	m.record("AccountRewardsExport", ctx, address, timeFrom, timeTo)
+	if m.AccountRewardsExportFunc != nil {
+		return m.AccountRewardsExportFunc(ctx, address, timeFrom, timeTo)
+	}
+	var r0 []byte
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) AccountTransfersExport(ctx context.Context, address string, optParams *AccountTransfersExportParams) ([]byte, error) {
+	m.record("AccountTransfersExport", ctx, address, optParams)
+	if m.AccountTransfersExportFunc != nil {
+		return m.AccountTransfersExportFunc(ctx, address, optParams)
+	}
+	var r0 []byte
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenTransfers(ctx context.Context, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
+	m.record("TokenTransfers", ctx, address, optParams)
+	if m.TokenTransfersFunc != nil {
+		return m.TokenTransfersFunc(ctx, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenTransfersPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
+	m.record("TokenTransfersPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.TokenTransfersPagingQueryFunc != nil {
+		return m.TokenTransfersPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenTransfersIter(ctx context.Context, address string, optParams *TokenTransfersParams) *Iterator[Transfer] {
+	m.record("TokenTransfersIter", ctx, address, optParams)
+	if m.TokenTransfersIterFunc != nil {
+		return m.TokenTransfersIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Transfer, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TokenTransfersShardQuery(ctx context.Context, shards int64, maxConcurrency int64, address string, optParams *TokenTransfersParams) ([]Transfer, error) {
+	m.record("TokenTransfersShardQuery", ctx, shards, maxConcurrency, address, optParams)
+	if m.TokenTransfersShardQueryFunc != nil {
+		return m.TokenTransfersShardQueryFunc(ctx, shards, maxConcurrency, address, optParams)
+	}
+	var r0 []Transfer
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenDefiActivities(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error) {
+	m.record("TokenDefiActivities", ctx, address, optParams)
+	if m.TokenDefiActivitiesFunc != nil {
+		return m.TokenDefiActivitiesFunc(ctx, address, optParams)
+	}
+	var r0 []DefiActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenDefiActivitiesPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenDefiActivitiesParams) ([]DefiActivity, error) {
+	m.record("TokenDefiActivitiesPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.TokenDefiActivitiesPagingQueryFunc != nil {
+		return m.TokenDefiActivitiesPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 []DefiActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenDefiActivitiesIter(ctx context.Context, address string, optParams *TokenDefiActivitiesParams) *Iterator[DefiActivity] {
+	m.record("TokenDefiActivitiesIter", ctx, address, optParams)
+	if m.TokenDefiActivitiesIterFunc != nil {
+		return m.TokenDefiActivitiesIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]DefiActivity, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TokenMarkets(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) ([]Market, error) {
+	m.record("TokenMarkets", ctx, token_pair, optParams)
+	if m.TokenMarketsFunc != nil {
+		return m.TokenMarketsFunc(ctx, token_pair, optParams)
+	}
+	var r0 []Market
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenMarketsPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, token_pair []string, optParams *TokenMarketsParams) ([]Market, error) {
+	m.record("TokenMarketsPagingQuery", ctx, startPage, totalSize, maxConcurrency, token_pair, optParams)
+	if m.TokenMarketsPagingQueryFunc != nil {
+		return m.TokenMarketsPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, token_pair, optParams)
+	}
+	var r0 []Market
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenMarketsIter(ctx context.Context, token_pair []string, optParams *TokenMarketsParams) *Iterator[Market] {
+	m.record("TokenMarketsIter", ctx, token_pair, optParams)
+	if m.TokenMarketsIterFunc != nil {
+		return m.TokenMarketsIterFunc(ctx, token_pair, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Market, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TokenList(ctx context.Context, optParams *TokenListParams) ([]Token, error) {
+	m.record("TokenList", ctx, optParams)
+	if m.TokenListFunc != nil {
+		return m.TokenListFunc(ctx, optParams)
+	}
+	var r0 []Token
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenListPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *TokenListParams) ([]Token, error) {
+	m.record("TokenListPagingQuery", ctx, startPage, totalSize, maxConcurrency, optParams)
+	if m.TokenListPagingQueryFunc != nil {
+		return m.TokenListPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, optParams)
+	}
+	var r0 []Token
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenListIter(ctx context.Context, optParams *TokenListParams) *Iterator[Token] {
+	m.record("TokenListIter", ctx, optParams)
+	if m.TokenListIterFunc != nil {
+		return m.TokenListIterFunc(ctx, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Token, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TokenTrending(ctx context.Context, limit int64) ([]Token, error) {
+	m.record("TokenTrending", ctx, limit)
+	if m.TokenTrendingFunc != nil {
+		return m.TokenTrendingFunc(ctx, limit)
+	}
+	var r0 []Token
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenPrice(ctx context.Context, address string, startTime string, endTime string) ([]TokenPrice, error) {
+	m.record("TokenPrice", ctx, address, startTime, endTime)
+	if m.TokenPriceFunc != nil {
+		return m.TokenPriceFunc(ctx, address, startTime, endTime)
+	}
+	var r0 []TokenPrice
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenHolders(ctx context.Context, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error) {
+	m.record("TokenHolders", ctx, address, optParams)
+	if m.TokenHoldersFunc != nil {
+		return m.TokenHoldersFunc(ctx, address, optParams)
+	}
+	var r0 RespDataWithTotal[TokenHolder]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenHoldersPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, address string, optParams *TokenHoldersParams) (RespDataWithTotal[TokenHolder], error) {
+	m.record("TokenHoldersPagingQuery", ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	if m.TokenHoldersPagingQueryFunc != nil {
+		return m.TokenHoldersPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, address, optParams)
+	}
+	var r0 RespDataWithTotal[TokenHolder]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TokenHoldersIter(ctx context.Context, address string, optParams *TokenHoldersParams) *Iterator[TokenHolder] {
+	m.record("TokenHoldersIter", ctx, address, optParams)
+	if m.TokenHoldersIterFunc != nil {
+		return m.TokenHoldersIterFunc(ctx, address, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]TokenHolder, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TokenMeta(ctx context.Context, address string) (TokenMeta, error) {
+	m.record("TokenMeta", ctx, address)
+	if m.TokenMetaFunc != nil {
+		return m.TokenMetaFunc(ctx, address)
+	}
+	var r0 TokenMeta
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BatchTokenMeta(ctx context.Context, addresses []string, maxConcurrency int64) (map[string]TokenMeta, map[string]error, error) {
+	m.record("BatchTokenMeta", ctx, addresses, maxConcurrency)
+	if m.BatchTokenMetaFunc != nil {
+		return m.BatchTokenMetaFunc(ctx, addresses, maxConcurrency)
+	}
+	var r0 map[string]TokenMeta
+	var r1 map[string]error
+	return r0, r1, ErrNotMocked
+}
+
+func (m *MockClient) TokenTop(ctx context.Context) ([]TokenTop, error) {
+	m.record("TokenTop", ctx)
+	if m.TokenTopFunc != nil {
+		return m.TokenTopFunc(ctx)
+	}
+	var r0 []TokenTop
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTNews(ctx context.Context, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error) {
+	m.record("NFTNews", ctx, optParams)
+	if m.NFTNewsFunc != nil {
+		return m.NFTNewsFunc(ctx, optParams)
+	}
+	var r0 RespDataWithTotal[NFTInfo]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTNewsPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTNewsParams) (RespDataWithTotal[NFTInfo], error) {
+	m.record("NFTNewsPagingQuery", ctx, startPage, totalSize, maxConcurrency, optParams)
+	if m.NFTNewsPagingQueryFunc != nil {
+		return m.NFTNewsPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, optParams)
+	}
+	var r0 RespDataWithTotal[NFTInfo]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTNewsIter(ctx context.Context, optParams *NFTNewsParams) *Iterator[NFTInfo] {
+	m.record("NFTNewsIter", ctx, optParams)
+	if m.NFTNewsIterFunc != nil {
+		return m.NFTNewsIterFunc(ctx, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]NFTInfo, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) NFTActivities(ctx context.Context, optParams *NFTActivitiesParams) ([]NFTActivity, error) {
+	m.record("NFTActivities", ctx, optParams)
+	if m.NFTActivitiesFunc != nil {
+		return m.NFTActivitiesFunc(ctx, optParams)
+	}
+	var r0 []NFTActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTActivitiesPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTActivitiesParams) ([]NFTActivity, error) {
+	m.record("NFTActivitiesPagingQuery", ctx, startPage, totalSize, maxConcurrency, optParams)
+	if m.NFTActivitiesPagingQueryFunc != nil {
+		return m.NFTActivitiesPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, optParams)
+	}
+	var r0 []NFTActivity
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTActivitiesIter(ctx context.Context, optParams *NFTActivitiesParams) *Iterator[NFTActivity] {
+	m.record("NFTActivitiesIter", ctx, optParams)
+	if m.NFTActivitiesIterFunc != nil {
+		return m.NFTActivitiesIterFunc(ctx, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]NFTActivity, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) NFTCollectionList(ctx context.Context, optParams *NFTCollectionListParams) ([]NFTCollection, error) {
+	m.record("NFTCollectionList", ctx, optParams)
+	if m.NFTCollectionListFunc != nil {
+		return m.NFTCollectionListFunc(ctx, optParams)
+	}
+	var r0 []NFTCollection
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTCollectionListPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *NFTCollectionListParams) ([]NFTCollection, error) {
+	m.record("NFTCollectionListPagingQuery", ctx, startPage, totalSize, maxConcurrency, optParams)
+	if m.NFTCollectionListPagingQueryFunc != nil {
+		return m.NFTCollectionListPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, optParams)
+	}
+	var r0 []NFTCollection
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTCollectionListIter(ctx context.Context, optParams *NFTCollectionListParams) *Iterator[NFTCollection] {
+	m.record("NFTCollectionListIter", ctx, optParams)
+	if m.NFTCollectionListIterFunc != nil {
+		return m.NFTCollectionListIterFunc(ctx, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]NFTCollection, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) NFTCollectionItems(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error) {
+	m.record("NFTCollectionItems", ctx, collection, optParams)
+	if m.NFTCollectionItemsFunc != nil {
+		return m.NFTCollectionItemsFunc(ctx, collection, optParams)
+	}
+	var r0 []NFTCollectionItem
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTCollectionItemsPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, collection string, optParams *NFTCollectionItemsParams) ([]NFTCollectionItem, error) {
+	m.record("NFTCollectionItemsPagingQuery", ctx, startPage, totalSize, maxConcurrency, collection, optParams)
+	if m.NFTCollectionItemsPagingQueryFunc != nil {
+		return m.NFTCollectionItemsPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, collection, optParams)
+	}
+	var r0 []NFTCollectionItem
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) NFTCollectionItemsIter(ctx context.Context, collection string, optParams *NFTCollectionItemsParams) *Iterator[NFTCollectionItem] {
+	m.record("NFTCollectionItemsIter", ctx, collection, optParams)
+	if m.NFTCollectionItemsIterFunc != nil {
+		return m.NFTCollectionItemsIterFunc(ctx, collection, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]NFTCollectionItem, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) TxLast(ctx context.Context, optParams *TxLastParams) ([]Transaction, error) {
+	m.record("TxLast", ctx, optParams)
+	if m.TxLastFunc != nil {
+		return m.TxLastFunc(ctx, optParams)
+	}
+	var r0 []Transaction
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) TxDetail(ctx context.Context, tx string) (TransactionDetail, error) {
+	m.record("TxDetail", ctx, tx)
+	if m.TxDetailFunc != nil {
+		return m.TxDetailFunc(ctx, tx)
+	}
+	var r0 TransactionDetail
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BatchTxDetail(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionDetail, map[string]error, error) {
+	m.record("BatchTxDetail", ctx, txs, maxConcurrency)
+	if m.BatchTxDetailFunc != nil {
+		return m.BatchTxDetailFunc(ctx, txs, maxConcurrency)
+	}
+	var r0 map[string]TransactionDetail
+	var r1 map[string]error
+	return r0, r1, ErrNotMocked
+}
+
+func (m *MockClient) TxActions(ctx context.Context, tx string) (TransactionAction, error) {
+	m.record("TxActions", ctx, tx)
+	if m.TxActionsFunc != nil {
+		return m.TxActionsFunc(ctx, tx)
+	}
+	var r0 TransactionAction
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BatchTxActions(ctx context.Context, txs []string, maxConcurrency int64) (map[string]TransactionAction, map[string]error, error) {
+	m.record("BatchTxActions", ctx, txs, maxConcurrency)
+	if m.BatchTxActionsFunc != nil {
+		return m.BatchTxActionsFunc(ctx, txs, maxConcurrency)
+	}
+	var r0 map[string]TransactionAction
+	var r1 map[string]error
+	return r0, r1, ErrNotMocked
+}
+
+func (m *MockClient) ChainInfo(ctx context.Context) (ChainInfo, error) {
+	m.record("ChainInfo", ctx)
+	if m.ChainInfoFunc != nil {
+		return m.ChainInfoFunc(ctx)
+	}
+	var r0 ChainInfo
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BlocksLast(ctx context.Context, limit LargePageSize) ([]BlockDetail, error) {
+	m.record("BlocksLast", ctx, limit)
+	if m.BlocksLastFunc != nil {
+		return m.BlocksLastFunc(ctx, limit)
+	}
+	var r0 []BlockDetail
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BlockTransactions(ctx context.Context, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error) {
+	m.record("BlockTransactions", ctx, block, optParams)
+	if m.BlockTransactionsFunc != nil {
+		return m.BlockTransactionsFunc(ctx, block, optParams)
+	}
+	var r0 RespDataWithTotal[Transaction]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BlockTransactionsPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, block int64, optParams *BlockTransactionsParams) (RespDataWithTotal[Transaction], error) {
+	m.record("BlockTransactionsPagingQuery", ctx, startPage, totalSize, maxConcurrency, block, optParams)
+	if m.BlockTransactionsPagingQueryFunc != nil {
+		return m.BlockTransactionsPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, block, optParams)
+	}
+	var r0 RespDataWithTotal[Transaction]
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) BlockTransactionsIter(ctx context.Context, block int64, optParams *BlockTransactionsParams) *Iterator[Transaction] {
+	m.record("BlockTransactionsIter", ctx, block, optParams)
+	if m.BlockTransactionsIterFunc != nil {
+		return m.BlockTransactionsIterFunc(ctx, block, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]Transaction, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) BlockDetail(ctx context.Context, block int64) (BlockDetail, error) {
+	m.record("BlockDetail", ctx, block)
+	if m.BlockDetailFunc != nil {
+		return m.BlockDetailFunc(ctx, block)
+	}
+	var r0 BlockDetail
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) PoolMarketList(ctx context.Context, optParams *PoolMarketListParams) ([]PoolMarket, error) {
+	m.record("PoolMarketList", ctx, optParams)
+	if m.PoolMarketListFunc != nil {
+		return m.PoolMarketListFunc(ctx, optParams)
+	}
+	var r0 []PoolMarket
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) PoolMarketListPagingQuery(ctx context.Context, startPage int64, totalSize int64, maxConcurrency int64, optParams *PoolMarketListParams) ([]PoolMarket, error) {
+	m.record("PoolMarketListPagingQuery", ctx, startPage, totalSize, maxConcurrency, optParams)
+	if m.PoolMarketListPagingQueryFunc != nil {
+		return m.PoolMarketListPagingQueryFunc(ctx, startPage, totalSize, maxConcurrency, optParams)
+	}
+	var r0 []PoolMarket
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) PoolMarketListIter(ctx context.Context, optParams *PoolMarketListParams) *Iterator[PoolMarket] {
+	m.record("PoolMarketListIter", ctx, optParams)
+	if m.PoolMarketListIterFunc != nil {
+		return m.PoolMarketListIterFunc(ctx, optParams)
+	}
+	return NewIterator(ctx, func(context.Context) ([]PoolMarket, bool, error) {
+		return nil, false, ErrNotMocked
+	})
+}
+
+func (m *MockClient) PoolMarketInfo(ctx context.Context, address string) (PoolMarketInfo, error) {
+	m.record("PoolMarketInfo", ctx, address)
+	if m.PoolMarketInfoFunc != nil {
+		return m.PoolMarketInfoFunc(ctx, address)
+	}
+	var r0 PoolMarketInfo
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) PoolMarketVolume(ctx context.Context, address string, startTime string, endTime string) (PoolMarketVolume, error) {
+	m.record("PoolMarketVolume", ctx, address, startTime, endTime)
+	if m.PoolMarketVolumeFunc != nil {
+		return m.PoolMarketVolumeFunc(ctx, address, startTime, endTime)
+	}
+	var r0 PoolMarketVolume
+	return r0, ErrNotMocked
+}
+
+func (m *MockClient) APIUsage(ctx context.Context) (APIUsage, error) {
+	m.record("APIUsage", ctx)
+	if m.APIUsageFunc != nil {
+		return m.APIUsageFunc(ctx)
+	}
+	var r0 APIUsage
+	return r0, ErrNotMocked
+}
@@ -0,0 +1,150 @@
+package go3s
+
+import (
+	"log/slog"
+	"net/http"
+	"strings"
+)
+
+// Option configures a Client.
+type Option func(c *Client)
+
+// WithToken sets the Solscan auth token.
+// An empty token falls back to SOLSCAN_AUTH_TOKEN.
+func WithToken(token string) Option {
+	return func(c *Client) {
+		c.headers["token"] = []string{token}
+	}
+}
+
+// WithProBaseURL overrides PRO_BASE_URL, e.g. to use a staging mirror or a local stand-in.
+func WithProBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.proBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithPublicBaseURL overrides PUBLIC_BASE_URL.
+func WithPublicBaseURL(baseURL string) Option {
+	return func(c *Client) {
+		c.publicBaseURL = strings.TrimRight(baseURL, "/")
+	}
+}
+
+// WithHeader adds an extra header sent with every request.
+func WithHeader(key string, values ...string) Option {
+	return func(c *Client) {
+		c.headers[key] = append(c.headers[key], values...)
+	}
+}
+
+// WithUserAgent sets the User-Agent header.
+func WithUserAgent(userAgent string) Option {
+	return func(c *Client) {
+		c.headers["User-Agent"] = []string{userAgent}
+	}
+}
+
+// WithLimiter sets the limiter every request waits on.
+// Pass the same limiter, e.g. V2Limiter, to several clients
+// to make them share one quota on purpose.
+func WithLimiter(limiter Limiter) Option {
+	return func(c *Client) {
+		c.limiter = limiter
+	}
+}
+
+// WithRequestsPerMinute gives the client its own limiter allowing rpm requests per minute,
+// spread evenly, with bursts of up to burst requests.
+func WithRequestsPerMinute(rpm, burst int) Option {
+	return func(c *Client) {
+		c.limiter = NewRequestsPerMinuteLimiter(rpm, burst)
+	}
+}
+
+// WithAdaptiveLimiter gives the client its own AdaptiveLimiter,
+// which slows down on 429 responses and recovers on success.
+func WithAdaptiveLimiter(minRPM, maxRPM, burst int) Option {
+	return func(c *Client) {
+		c.limiter = NewAdaptiveLimiter(minRPM, maxRPM, burst)
+	}
+}
+
+// WithRetryPolicy sets the GetterOption used by single page requests.
+func WithRetryPolicy(opt *GetterOption) Option {
+	return func(c *Client) {
+		c.getterOption = opt
+	}
+}
+
+// WithPagingRetryPolicy sets the GetterOption used by PagingQuery methods.
+func WithPagingRetryPolicy(opt *GetterOption) Option {
+	return func(c *Client) {
+		c.pagingOption = opt
+	}
+}
+
+// WithMaxPages caps the pages fetched by a single PagingQuery call, 0 means no cap.
+// It mostly matters with a totalSize of 0, which fetches all pages.
+func WithMaxPages(maxPages int64) Option {
+	return func(c *Client) {
+		c.maxPages = maxPages
+	}
+}
+
+// WithPartialResults makes PagingQuery methods return the pages fetched
+// along with a PageErrors listing the failed pages, instead of failing as a whole.
+func WithPartialResults() Option {
+	return func(c *Client) {
+		c.partialResults = true
+	}
+}
+
+// WithCUBudget sets the compute unit budget charged by every request.
+// By default each client tracks its spend in an unlimited budget.
+func WithCUBudget(b *CUBudget) Option {
+	return func(c *Client) {
+		c.cuBudget = b
+	}
+}
+
+// WithCUCosts sets the CU cost of every endpoint, nil means DefaultCUCosts,
+// e.g. to follow a pricing change before the package does.
+func WithCUCosts(costs CUCosts) Option {
+	return func(c *Client) {
+		c.cuCosts = costs
+	}
+}
+
+// WithCache caches the responses of the endpoints in policy, nil means DefaultCachePolicy,
+// e.g. WithCache(NewLRUCache(10000), nil).
+func WithCache(cache Cache, policy CachePolicy) Option {
+	return func(c *Client) {
+		c.cache = cache
+		c.cachePolicy = policy
+	}
+}
+
+// WithLogger sets the logger used for retries and paging progress.
+func WithLogger(l *slog.Logger) Option {
+	return func(c *Client) {
+		c.logger = l
+	}
+}
+
+// WithHTTPClient sets the http.Client used for every request,
+// so timeouts, proxies and connection pools can be tuned.
+// A nil hc falls back to http.DefaultClient.
+func WithHTTPClient(hc *http.Client) Option {
+	return func(c *Client) {
+		c.httpClient = hc
+	}
+}
+
+// WithTransport sets the http.RoundTripper used for every request.
+// It is a shortcut for WithHTTPClient(&http.Client{Transport: rt}).
+func WithTransport(rt http.RoundTripper) Option {
+	return func(c *Client) {
+		c.httpClient = &http.Client{Transport: rt}
+	}
+}
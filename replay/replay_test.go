@@ -0,0 +1,61 @@
+package replay
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecordReplay(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Set-Cookie", "session=secret-cookie")
+		io.WriteString(w, `{"success":true,"data":"`+r.URL.Query().Get("page")+`"}`)
+	}))
+	defer srv.Close()
+	dir := t.TempDir()
+
+	get := func(client *http.Client, page string) (string, error) {
+		req, _ := http.NewRequest(http.MethodGet, srv.URL+"/account/transfer?page="+page+"&token=secret-token", nil)
+		req.Header.Set("token", "secret-token")
+		resp, err := client.Do(req)
+		if err != nil {
+			return "", err
+		}
+		defer resp.Body.Close()
+		b, err := io.ReadAll(resp.Body)
+		return string(b), err
+	}
+
+	recorder := &http.Client{Transport: &Transport{Dir: dir, Record: true}}
+	want, err := get(recorder, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	files, _ := filepath.Glob(filepath.Join(dir, "*.json"))
+	if len(files) != 1 {
+		t.Fatalf("fixtures = %v, want 1", files)
+	}
+	b, _ := os.ReadFile(files[0])
+	if strings.Contains(string(b), "secret") {
+		t.Fatalf("fixture leaks credentials:\n%s", b)
+	}
+
+	srv.Close()
+	replayer := &http.Client{Transport: &Transport{Dir: dir}}
+	got, err := get(replayer, "1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != want {
+		t.Fatalf("replayed %s, want %s", got, want)
+	}
+	if _, err := get(replayer, "2"); !errors.Is(err, ErrNoFixture) {
+		t.Fatalf("err = %v, want ErrNoFixture", err)
+	}
+}
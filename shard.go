@@ -0,0 +1,290 @@
+package go3s
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"sync"
+)
+
+// SHARD_MAX_PAGES is the deepest page fetched from a block time window
+// before the rest of the window is split into smaller windows.
+const SHARD_MAX_PAGES = 100
+
+var ErrShardOverflow = fmt.Errorf("solscan: block time window can not be split further")
+
+// ShardGetter fetches the block time range [From, To] as adjacent windows,
+// so endpoints capping how deep pages go can still be fully fetched.
+// Windows are fetched concurrently. When a window holds more items than Fetch
+// can reach, the part of the window that was not reached is bisected and fetched again.
+// The results are merged in block time order and deduplicated by Key.
+//
+// MaxConcurrency bounds the windows fetched at once, not the requests of Fetch.
+// The ShardQuery methods of Client share one bound of maxConcurrency requests
+// across all windows, bisected ones included, and fetch the pages of a window
+// concurrently, so even a single shard uses every request slot.
+type ShardGetter[T any] struct {
+	From           int64
+	To             int64
+	Shards         int64 // initial number of windows
+	MaxConcurrency int64
+	// Ascending is the block time order of Fetch results and of the merged results.
+	Ascending bool
+	// Fetch fetches the items of the window [from, to] in block time order,
+	// reporting whether the window has more items than it could reach.
+	Fetch     func(ctx context.Context, from, to int64) (items []T, more bool, err error)
+	BlockTime func(t T) int64
+	Key       func(t T) string
+	Logger    *slog.Logger // nil means the package logger
+}
+
+func (g *ShardGetter[T]) Do(ctx context.Context) ([]T, error) {
+	if g.From > g.To {
+		return nil, fmt.Errorf("solscan: invalid block time range [%d, %d]", g.From, g.To)
+	}
+	maxConcurrency := g.MaxConcurrency
+	if maxConcurrency <= 0 {
+		maxConcurrency = 1
+	}
+	log := g.Logger
+	if log == nil {
+		log = logger
+	}
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		items    []T
+		firstErr error
+	)
+	fail := func(err error) {
+		mu.Lock()
+		defer mu.Unlock()
+		if firstErr == nil {
+			firstErr = err
+			cancel()
+		}
+	}
+	sem := make(chan struct{}, maxConcurrency)
+	var fetch func(from, to int64)
+	fetch = func(from, to int64) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+			ts, more, err := g.Fetch(ctx, from, to)
+			<-sem
+			if err != nil {
+				fail(err)
+				return
+			}
+			mu.Lock()
+			items = append(items, ts...)
+			mu.Unlock()
+			if !more || len(ts) == 0 {
+				return
+			}
+			// Items are in order, so only the window part past the last item is left.
+			restFrom, restTo := from, g.BlockTime(ts[len(ts)-1])
+			if g.Ascending {
+				restFrom, restTo = restTo, to
+			}
+			if from == to {
+				fail(fmt.Errorf("%w: %d", ErrShardOverflow, from))
+				return
+			}
+			log.Info("solscan: splitting block time window", "from", restFrom, "to", restTo)
+			for _, w := range splitWindow(restFrom, restTo, 2) {
+				fetch(w[0], w[1])
+			}
+		}()
+	}
+	shards := g.Shards
+	if shards <= 0 {
+		shards = 1
+	}
+	for _, w := range splitWindow(g.From, g.To, shards) {
+		fetch(w[0], w[1])
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	return g.merge(items), nil
+}
+
+// merge sorts items by block time and drops items with a Key already seen.
+func (g *ShardGetter[T]) merge(items []T) []T {
+	sort.SliceStable(items, func(i, j int) bool {
+		if g.Ascending {
+			return g.BlockTime(items[i]) < g.BlockTime(items[j])
+		}
+		return g.BlockTime(items[i]) > g.BlockTime(items[j])
+	})
+	if g.Key == nil {
+		return items
+	}
+	seen := make(map[string]struct{}, len(items))
+	merged := items[:0]
+	for _, t := range items {
+		k := g.Key(t)
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		merged = append(merged, t)
+	}
+	return merged
+}
+
+// splitWindow splits [from, to] into at most n adjacent windows of about the same length.
+func splitWindow(from, to, n int64) [][2]int64 {
+	span := to - from + 1
+	if n > span {
+		n = span
+	}
+	windows := make([][2]int64, 0, n)
+	for i := int64(0); i < n; i++ {
+		windows = append(windows, [2]int64{from + span*i/n, from + span*(i+1)/n - 1})
+	}
+	return windows
+}
+
+// newSliceShardGetter shards g, a paging getter of an endpoint taking block_time and page_size,
+// over the block_time range of its params, with at most maxConcurrency requests in flight.
+func newSliceShardGetter[T any](g PagingGetter[[]T], shards, maxConcurrency int64, blockTime func(T) int64, key func(T) string) (*ShardGetter[T], error) {
+	blockTimeRange := g.Params["block_time[]"]
+	if len(blockTimeRange) != 2 {
+		return nil, fmt.Errorf("solscan: sharding needs a BlockTimeRange of [from, to]")
+	}
+	from, err := strconv.ParseInt(blockTimeRange[0], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("solscan: invalid BlockTimeRange: %w", err)
+	}
+	to, err := strconv.ParseInt(blockTimeRange[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("solscan: invalid BlockTimeRange: %w", err)
+	}
+	pageSize, err := g.pageSize()
+	if err != nil {
+		return nil, err
+	}
+	pp := g.PagingParams
+	maxConcurrency = max(1, maxConcurrency)
+	g.HTTPClient = withRequestBound(g.HTTPClient, maxConcurrency)
+	sg := &ShardGetter[T]{
+		From:           from,
+		To:             to,
+		Shards:         shards,
+		MaxConcurrency: maxConcurrency,
+		Ascending:      g.Params.Get("sort_order") == string(SortOrderAsc),
+		BlockTime:      blockTime,
+		Key:            key,
+	}
+	if g.GetterOption != nil {
+		sg.Logger = g.GetterOption.Logger
+	}
+	sg.Fetch = func(ctx context.Context, from, to int64) ([]T, bool, error) {
+		pg := g
+		pg.Params = windowParams(g.Params, from, to)
+		pg.PagingParams = &PagingParams[[]T]{
+			StartPage:                1,
+			MaxPages:                 SHARD_MAX_PAGES,
+			MaxConcurrency:           maxConcurrency,
+			DataFinishCheckerCreator: pp.DataFinishCheckerCreator,
+			PageSizes:                pp.PageSizes,
+			ResultsHandler:           CreateSliceResultsHandler[T](0),
+		}
+		ts, err := pg.doPages(ctx)
+		if err != nil {
+			return nil, false, err
+		}
+		return ts, int64(len(ts)) >= SHARD_MAX_PAGES*pageSize, nil
+	}
+	return sg, nil
+}
+
+// withRequestBound returns a copy of hc letting at most n requests be in flight.
+func withRequestBound(hc *http.Client, n int64) *http.Client {
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	c := *hc
+	rt := c.Transport
+	if rt == nil {
+		rt = http.DefaultTransport
+	}
+	c.Transport = &boundedTransport{sem: make(chan struct{}, n), rt: rt}
+	return &c
+}
+
+// boundedTransport holds a slot of sem from the start of a request
+// until its response body is closed.
+type boundedTransport struct {
+	sem chan struct{}
+	rt  http.RoundTripper
+}
+
+func (t *boundedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	select {
+	case t.sem <- struct{}{}:
+	case <-req.Context().Done():
+		return nil, req.Context().Err()
+	}
+	resp, err := t.rt.RoundTrip(req)
+	if err != nil {
+		<-t.sem
+		return nil, err
+	}
+	resp.Body = &boundedBody{ReadCloser: resp.Body, release: sync.OnceFunc(func() { <-t.sem })}
+	return resp, nil
+}
+
+type boundedBody struct {
+	io.ReadCloser
+	release func()
+}
+
+func (b *boundedBody) Close() error {
+	err := b.ReadCloser.Close()
+	b.release()
+	return err
+}
+
+func windowParams(params url.Values, from, to int64) url.Values {
+	p := cloneParams(params)
+	p.Del("page")
+	p["block_time[]"] = []string{strconv.FormatInt(from, 10), strconv.FormatInt(to, 10)}
+	return p
+}
+
+// TransferKey identifies a transfer. A transaction may make several transfers,
+// so the trans_id is combined with the fields telling them apart.
+func TransferKey(t Transfer) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%s/%d", t.TransID, t.ActivityType, t.FromAddress, t.ToAddress, t.TokenAddress, t.Amount)
+}
+
+// DefiActivityKey identifies a defi activity.
+func DefiActivityKey(a DefiActivity) string {
+	return fmt.Sprintf("%s/%s/%s/%s", a.TransID, a.ActivityType, a.FromAddress, a.ToAddress)
+}
+
+// BalanceChangeKey identifies a balance change.
+func BalanceChangeKey(a AccountChangeActivity) string {
+	return fmt.Sprintf("%s/%s/%s/%s/%d", a.TransID, a.TokenAccount, a.TokenAddress, a.ChangeType, a.Amount)
+}
@@ -0,0 +1,184 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+type shardItem struct {
+	id   string
+	time int64
+}
+
+// cappedFetch returns the items of a window newest first, at most depth of them.
+func cappedFetch(items []shardItem, depth int) func(ctx context.Context, from, to int64) ([]shardItem, bool, error) {
+	return func(ctx context.Context, from, to int64) ([]shardItem, bool, error) {
+		var ts []shardItem
+		for _, it := range items {
+			if it.time >= from && it.time <= to {
+				ts = append(ts, it)
+			}
+		}
+		if len(ts) > depth {
+			return ts[:depth], true, nil
+		}
+		return ts, false, nil
+	}
+}
+
+func newShardItems(from, to int64, perSecond int) []shardItem {
+	var items []shardItem
+	for t := to; t >= from; t-- {
+		for i := 0; i < perSecond; i++ {
+			items = append(items, shardItem{id: strconv.FormatInt(t, 10) + "/" + strconv.Itoa(i), time: t})
+		}
+	}
+	return items
+}
+
+func TestShardGetter(t *testing.T) {
+	items := newShardItems(1, 100, 3)
+	g := &ShardGetter[shardItem]{
+		From:           1,
+		To:             100,
+		Shards:         2,
+		MaxConcurrency: 3,
+		Fetch:          cappedFetch(items, 20),
+		BlockTime:      func(it shardItem) int64 { return it.time },
+		Key:            func(it shardItem) string { return it.id },
+	}
+	got, err := g.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(items) {
+		t.Fatalf("items = %d, want %d", len(got), len(items))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].time > got[i-1].time {
+			t.Fatalf("item %d is out of order", i)
+		}
+	}
+
+	g.Fetch = cappedFetch(newShardItems(1, 100, 30), 20)
+	if _, err := g.Do(context.Background()); !errors.Is(err, ErrShardOverflow) {
+		t.Fatalf("err = %v, want ErrShardOverflow", err)
+	}
+}
+
+func TestAccountTransfersShardQuery(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		from, _ := strconv.ParseInt(q["block_time[]"][0], 10, 64)
+		to, _ := strconv.ParseInt(q["block_time[]"][1], 10, 64)
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("page_size"))
+		var all []Transfer
+		for bt := to; bt >= from; bt-- {
+			if bt <= 50 {
+				all = append(all, Transfer{TransID: strconv.FormatInt(bt, 10), BlockTime: bt})
+			}
+		}
+		data := []Transfer{}
+		for i := (page - 1) * size; i < page*size && i < len(all); i++ {
+			data = append(data, all[i])
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithLimiter(rate.NewLimiter(rate.Inf, 1)))
+
+	transfers, err := client.AccountTransfersShardQuery(context.Background(), 4, 2, "addr", &AccountTransfersParams{
+		BlockTimeRange: []int64{1, 60},
+		PageSize:       LargePageSize10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 50 || transfers[0].BlockTime != 50 || transfers[49].BlockTime != 1 {
+		t.Fatalf("transfers = %d, want 50 newest first", len(transfers))
+	}
+
+	if _, err := client.AccountTransfersShardQuery(context.Background(), 4, 2, "addr", nil); err == nil {
+		t.Fatal("want an error without a BlockTimeRange")
+	}
+}
+
+func TestAccountTransfersShardQueryPageConcurrency(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(10 * time.Millisecond)
+		page, _ := strconv.Atoi(r.URL.Query().Get("page"))
+		data := []Transfer{}
+		for i := (page - 1) * 10; i < page*10 && i < 80; i++ {
+			data = append(data, Transfer{TransID: strconv.Itoa(i), BlockTime: 100})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithLimiter(rate.NewLimiter(rate.Inf, 1)))
+
+	transfers, err := client.AccountTransfersShardQuery(context.Background(), 1, 4, "addr", &AccountTransfersParams{
+		BlockTimeRange: []int64{1, 100},
+		PageSize:       LargePageSize10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 80 {
+		t.Fatalf("transfers = %d, want 80", len(transfers))
+	}
+	if p := peak.Load(); p < 2 || p > 4 {
+		t.Fatalf("peak requests in flight = %d, want 2 to 4", p)
+	}
+}
+
+func TestAccountTransfersShardQueryBoundsRequests(t *testing.T) {
+	var inFlight, peak atomic.Int64
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := inFlight.Add(1)
+		defer inFlight.Add(-1)
+		for p := peak.Load(); n > p && !peak.CompareAndSwap(p, n); p = peak.Load() {
+		}
+		time.Sleep(2 * time.Millisecond)
+		q := r.URL.Query()
+		from, _ := strconv.ParseInt(q["block_time[]"][0], 10, 64)
+		to, _ := strconv.ParseInt(q["block_time[]"][1], 10, 64)
+		page, _ := strconv.ParseInt(q.Get("page"), 10, 64)
+		data := []Transfer{}
+		for bt := to - (page-1)*10; bt > to-page*10 && bt >= from; bt-- {
+			data = append(data, Transfer{TransID: strconv.FormatInt(bt, 10), BlockTime: bt})
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL), WithLimiter(rate.NewLimiter(rate.Inf, 1)))
+
+	// Both windows hold more items than SHARD_MAX_PAGES pages and are bisected.
+	transfers, err := client.AccountTransfersShardQuery(context.Background(), 2, 4, "addr", &AccountTransfersParams{
+		BlockTimeRange: []int64{1, 2500},
+		PageSize:       LargePageSize10,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 2500 {
+		t.Fatalf("transfers = %d, want 2500", len(transfers))
+	}
+	if p := peak.Load(); p > 4 {
+		t.Fatalf("peak requests in flight = %d, want at most 4", p)
+	}
+}
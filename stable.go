@@ -0,0 +1,132 @@
+package go3s
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+)
+
+// BlockTimeGap is a block time range, in seconds, whose items may be incomplete.
+type BlockTimeGap struct {
+	From int64
+	To   int64
+}
+
+// StableGetter fetches the items of a block time range newest first, while new activity
+// keeps arriving. The upper bound of the range is pinned when Do starts, and every
+// request narrows it down to the block time of the last item seen, instead of asking
+// for the next page number, so items can not shift between pages.
+// The items of the second shared by two requests are deduplicated by Key.
+type StableGetter[T any] struct {
+	From int64
+	// To is the upper bound of the range, 0 pins it to the current time.
+	To       int64
+	PageSize int64
+	// MaxPages caps the pages fetched from a single second holding more than a page
+	// of items. The rest of such a second is reported as a BlockTimeGap.
+	MaxPages int64
+	// TotalSize is the number of items to fetch, 0 or less fetches all.
+	TotalSize int64
+	// Fetch fetches the page of the items in [from, to], newest first.
+	Fetch     func(ctx context.Context, from, to, page int64) ([]T, error)
+	BlockTime func(t T) int64
+	Key       func(t T) string
+}
+
+// Do returns the items fetched, newest first, and the gaps detected.
+// On failure the items fetched so far are returned with the error.
+func (g *StableGetter[T]) Do(ctx context.Context) ([]T, []BlockTimeGap, error) {
+	upper := g.To
+	if upper <= 0 {
+		upper = time.Now().Unix()
+	}
+	var (
+		items = []T{}
+		gaps  []BlockTimeGap
+		seen  = map[string]struct{}{}
+	)
+	add := func(ts []T) {
+		for _, t := range ts {
+			k := g.Key(t)
+			if _, ok := seen[k]; ok {
+				continue
+			}
+			seen[k] = struct{}{}
+			items = append(items, t)
+		}
+	}
+	full := func() bool {
+		return g.TotalSize > 0 && int64(len(items)) >= g.TotalSize
+	}
+	for upper >= g.From && !full() {
+		ts, err := g.Fetch(ctx, g.From, upper, 1)
+		if err != nil {
+			return items, gaps, err
+		}
+		add(ts)
+		if int64(len(ts)) < g.PageSize {
+			break
+		}
+		first, last := g.BlockTime(ts[0]), g.BlockTime(ts[len(ts)-1])
+		if first != last {
+			// The next request starts again at the last second,
+			// whose items may not all fit in this page.
+			upper = last
+			continue
+		}
+		// A whole page of a single second, the bound can not narrow it down.
+		for page := int64(2); !full(); page++ {
+			if g.MaxPages > 0 && page > g.MaxPages {
+				gaps = append(gaps, BlockTimeGap{From: last, To: last})
+				break
+			}
+			ts, err := g.Fetch(ctx, last, last, page)
+			if err != nil {
+				return items, gaps, err
+			}
+			add(ts)
+			if int64(len(ts)) < g.PageSize {
+				break
+			}
+		}
+		upper = last - 1
+	}
+	return items[:truncatedLen(len(items), g.TotalSize)], gaps, nil
+}
+
+// newSliceStableGetter creates a StableGetter over sg, a getter of an endpoint
+// taking block_time, page and page_size, within the block_time range of its params.
+func newSliceStableGetter[T any](sg SimpleGetter[[]T], pageSizes []int64, blockTime func(T) int64, key func(T) string) (*StableGetter[T], error) {
+	pageSize, err := strconv.ParseInt(sg.Params.Get("page_size"), 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("solscan: can not get page_size: %s", err.Error())
+	}
+	if !slices.Contains(pageSizes, pageSize) {
+		return nil, fmt.Errorf("%w: %d, must be one of %v", ErrInvalidPageSize, pageSize, pageSizes)
+	}
+	g := &StableGetter[T]{
+		PageSize:  pageSize,
+		MaxPages:  SHARD_MAX_PAGES,
+		BlockTime: blockTime,
+		Key:       key,
+	}
+	if blockTimeRange := sg.Params["block_time[]"]; len(blockTimeRange) == 2 {
+		if g.From, err = strconv.ParseInt(blockTimeRange[0], 10, 64); err != nil {
+			return nil, fmt.Errorf("solscan: invalid BlockTimeRange: %w", err)
+		}
+		if g.To, err = strconv.ParseInt(blockTimeRange[1], 10, 64); err != nil {
+			return nil, fmt.Errorf("solscan: invalid BlockTimeRange: %w", err)
+		}
+	}
+	g.Fetch = func(ctx context.Context, from, to, page int64) ([]T, error) {
+		pg := sg
+		pg.Params = windowParams(sg.Params, from, to)
+		pg.Params.Set("page", strconv.FormatInt(page, 10))
+		pg.Params.Set("sort_by", string(SortByBlockTime))
+		pg.Params.Set("sort_order", string(SortOrderDesc))
+		return pg.Do(ctx)
+	}
+	return g, nil
+}
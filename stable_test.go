@@ -0,0 +1,109 @@
+package go3s
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+// liveItems serves items newest first and adds a newer item on every fetch.
+type liveItems struct {
+	mu    sync.Mutex
+	items []shardItem
+}
+
+func (l *liveItems) fetch(pageSize int64) func(ctx context.Context, from, to, page int64) ([]shardItem, error) {
+	return func(ctx context.Context, from, to, page int64) ([]shardItem, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		l.items = append([]shardItem{{id: "new" + strconv.Itoa(len(l.items)), time: 1000}}, l.items...)
+		var window []shardItem
+		for _, it := range l.items {
+			if it.time >= from && it.time <= to {
+				window = append(window, it)
+			}
+		}
+		start := (page - 1) * pageSize
+		if start >= int64(len(window)) {
+			return nil, nil
+		}
+		return window[start:min(start+pageSize, int64(len(window)))], nil
+	}
+}
+
+func TestStableGetter(t *testing.T) {
+	items := newShardItems(51, 100, 2)
+	items = append(items, newShardItems(50, 50, 25)...)
+	items = append(items, newShardItems(1, 49, 2)...)
+	live := &liveItems{items: items}
+	g := &StableGetter[shardItem]{
+		From:      1,
+		To:        100,
+		PageSize:  10,
+		Fetch:     live.fetch(10),
+		BlockTime: func(it shardItem) int64 { return it.time },
+		Key:       func(it shardItem) string { return it.id },
+	}
+	got, gaps, err := g.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != len(items) || len(gaps) != 0 {
+		t.Fatalf("items = %d, gaps = %v, want %d and none", len(got), gaps, len(items))
+	}
+	for i := 1; i < len(got); i++ {
+		if got[i].time > got[i-1].time {
+			t.Fatalf("item %d is out of order", i)
+		}
+	}
+
+	g.MaxPages = 2
+	got, gaps, err = g.Do(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gaps) != 1 || gaps[0] != (BlockTimeGap{From: 50, To: 50}) {
+		t.Fatalf("gaps = %v, want second 50", gaps)
+	}
+	if len(got) != len(items)-5 {
+		t.Fatalf("items = %d, want %d", len(got), len(items)-5)
+	}
+}
+
+func TestAccountTransfersStableQuery(t *testing.T) {
+	now := time.Now().Unix()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		to, _ := strconv.ParseInt(q["block_time[]"][1], 10, 64)
+		page, _ := strconv.Atoi(q.Get("page"))
+		size, _ := strconv.Atoi(q.Get("page_size"))
+		var all []Transfer
+		for bt := to; bt > now-45; bt-- {
+			all = append(all, Transfer{TransID: strconv.FormatInt(bt, 10), BlockTime: bt})
+		}
+		data := []Transfer{}
+		for i := (page - 1) * size; i < page*size && i < len(all); i++ {
+			data = append(data, all[i])
+		}
+		json.NewEncoder(w).Encode(RespData[[]Transfer]{Success: true, Data: data})
+	}))
+	defer srv.Close()
+	client := NewClient(WithProBaseURL(srv.URL))
+	transfers, gaps, err := client.AccountTransfersStableQuery(context.Background(), 0, "addr", &AccountTransfersParams{PageSize: LargePageSize10})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(gaps) != 0 || len(transfers) < 45 || transfers[0].BlockTime > now+1 {
+		t.Fatalf("transfers = %d, gaps = %v", len(transfers), gaps)
+	}
+	for i := 1; i < len(transfers); i++ {
+		if transfers[i].TransID == transfers[i-1].TransID {
+			t.Fatalf("transfer %s is duplicated", transfers[i].TransID)
+		}
+	}
+}
@@ -65,3 +65,11 @@ func toParams(s any) (params url.Values) {
 	}
 	return
 }
+
+func cloneParams(params url.Values) url.Values {
+	p := make(url.Values, len(params))
+	for k, v := range params {
+		p[k] = append([]string(nil), v...)
+	}
+	return p
+}